@@ -6,5 +6,8 @@
 // annotate the same error chain twice, this way you can avoid cluttered the error
 // logs.
 //
+// Error can also be annotated with stack trace without changing its text, see WithStack
+// and Stack, log drivers can be configured to render it when the error is logged.
+//
 // Other method are simply calling standard errors method of the same name.
 package errors
@@ -42,3 +42,10 @@ func ExampleErrorf_other() {
 	// Output:
 	// github.com/hexastack-dev/devkit-go/errors_test/errors_example_test.go:40: dang: oopsie
 }
+
+func ExampleNew_withStack() {
+	err := errors.New("oopsie", errors.WithStack(1))
+	fmt.Println(err)
+	// Output:
+	// oopsie
+}
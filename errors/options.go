@@ -9,3 +9,12 @@ func WithTag(callerSkip int) Option {
 		return Tag(err, callerSkip+2) // skip 2, 1 for inner function and 1 for outer function (WithTag)
 	}
 }
+
+// WithStack annotate error with stack trace of the caller, the stack trace is not
+// part of the error text and can be retrieved using StackTrace. Logger drivers use
+// it to render the stack trace of logged error.
+func WithStack(callerSkip int) Option {
+	return func(err error) error {
+		return Stack(err, callerSkip+2) // skip 2, 1 for inner function and 1 for outer function (WithStack)
+	}
+}
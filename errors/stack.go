@@ -0,0 +1,46 @@
+package errors
+
+import "runtime"
+
+const maxStackDepth = 32
+
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace return program counters of the stack captured when the error is annotated,
+// use runtime.CallersFrames to resolve them.
+func (e *stackError) StackTrace() []uintptr {
+	return e.stack
+}
+
+// Stack annotate given error with stack trace, skip is the number of stack frames to
+// skip in the same way as Tag, ie. 1 starts the stack trace from the caller of Stack.
+// The error text is not changed.
+func Stack(err error, skip int) error {
+	if err == nil {
+		return nil
+	}
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+1, pcs) // skip 1 for runtime.Callers
+	return &stackError{err: err, stack: pcs[:n]}
+}
+
+// StackTrace return stack trace of the first error in err's chain which is annotated
+// with stack trace, see Stack and WithStack.
+func StackTrace(err error) ([]uintptr, bool) {
+	var st interface{ StackTrace() []uintptr }
+	if As(err, &st) {
+		return st.StackTrace(), true
+	}
+	return nil, false
+}
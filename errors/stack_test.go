@@ -0,0 +1,37 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithStack(t *testing.T) {
+	err := errors.New("oopsie", errors.WithStack(1))
+	assert.Equal(t, "oopsie", err.Error())
+
+	pcs, ok := errors.StackTrace(fmt.Errorf("dang: %w", err))
+	assert.True(t, ok)
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	assert.Equal(t, "github.com/hexastack-dev/devkit-go/errors_test.TestNew_WithStack", frame.Function)
+
+	_, ok = errors.StackTrace(errors.New("oopsie"))
+	assert.False(t, ok)
+}
+
+func TestStack(t *testing.T) {
+	err1 := stderrors.New("oopsie")
+	err2 := errors.Stack(err1, 1)
+
+	assert.ErrorIs(t, err2, err1)
+	assert.Equal(t, "oopsie", err2.Error())
+	pcs, ok := errors.StackTrace(err2)
+	assert.True(t, ok)
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	assert.Equal(t, "github.com/hexastack-dev/devkit-go/errors_test.TestStack", frame.Function)
+	assert.Nil(t, errors.Stack(nil, 1))
+}
@@ -61,12 +61,12 @@ func New[T jwt.Claims](keyfunc jwt.Keyfunc, claimFactory func() jwt.Claims, mapp
 			if verifier, ok := rawVerifier.(AudienceVerifier); ok {
 				if ok := verifier.VerifyAudience("oidc-extension", true); !ok {
 					err := errors.Tag(jwt.ErrTokenInvalidAudience, 1)
-					log.Error("Invalid audience", err)
+					log.WithContext(r.Context()).Error("Invalid audience", err)
 					w.WriteHeader(http.StatusForbidden)
 					return
 				}
 			} else {
-				log.Warn("Underlying Claims is not a AudienceVerifier")
+				log.WithContext(r.Context()).Warn("Underlying Claims is not a AudienceVerifier")
 			}
 
 			// u := &principal.User{
@@ -76,6 +76,7 @@ func New[T jwt.Claims](keyfunc jwt.Keyfunc, claimFactory func() jwt.Claims, mapp
 			// addUserRoles(u, claims.RealmAccess, claims.ResourceAccess)
 
 			u := mapper(claims)
+			// register principal.UserExtractor for downstream logs to carry the authenticated user id.
 			ctx := principal.ContextWithUser(r.Context(), &u)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
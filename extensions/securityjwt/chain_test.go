@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
 	"github.com/hexastack-dev/devkit-go/security/principal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +62,31 @@ func TestNewKeycloak(t *testing.T) {
 	t.Run("Test authenticated", testAuthenticated(h))
 }
 
+func TestNew_ContextLogger(t *testing.T) {
+	ignoreExpiration = true
+	t.Cleanup(func() { ignoreExpiration = false })
+	log.AddContextExtractor(principal.UserExtractor)
+	t.Cleanup(func() { log.SetContextExtractors(log.RequestIDExtractor) })
+	tl := logtest.SetGlobal(t)
+	h := NewKeycloak(keyFunc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.WithContext(r.Context()).Info("Hello")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+tokenString)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	ent := tl.AssertLogged(t, log.InfoLogLevel, "Hello")
+	assert.Equal(t, "4a3dcdc4-2e14-4d7e-9c3f-db81a24df924", ent.FieldValue("userId"))
+	n := 0
+	for _, f := range append(ent.Fields, ent.ContextFields...) {
+		if f.Key == "userId" {
+			n++
+		}
+	}
+	assert.Equal(t, 1, n)
+}
+
 /*
 func TestIntegrationKeycloak(t *testing.T) {
 	require.NotEmpty(t, os.Getenv("JWKS_URL"))
@@ -0,0 +1,252 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrAsyncWriterClosed is returned when writing into closed AsyncWriter.
+var ErrAsyncWriterClosed = errors.New("async writer is closed")
+
+// OverflowPolicy define what AsyncWriter should do when its queue is full.
+type OverflowPolicy uint8
+
+const (
+	// BlockOnOverflow blocks Write until the queue has room.
+	BlockOnOverflow OverflowPolicy = iota
+	// DropNewestOnOverflow drops the log being written.
+	DropNewestOnOverflow
+	// DropOldestOnOverflow drops the oldest log in the queue to make room for the log being written.
+	DropOldestOnOverflow
+)
+
+// AsyncWriterConfig define configurations for AsyncWriter.
+type AsyncWriterConfig struct {
+	// QueueSize is the maximum number of pending logs. Default to 1024.
+	QueueSize int
+	// BufferSize is the size of buffer in bytes used to batch writes into underlying writer.
+	// Default to 4096.
+	BufferSize int
+	// FlushInterval is the interval to flush the buffer into underlying writer. Default to 1 second.
+	FlushInterval time.Duration
+	// OverflowPolicy define what to do when the queue is full. Default to BlockOnOverflow.
+	OverflowPolicy OverflowPolicy
+}
+
+// AsyncWriter is buffered io.Writer which writes into underlying writer in background goroutine,
+// this avoids slow writer, such as stdout pipe, from stalling the caller. AsyncWriter can be used
+// as SimpleLogger writer or zaplog's Config.Output.
+//
+// Close must be called before the program exit to flush pending logs, AsyncWriter also implements
+// shutdown.Listener thus it can be registered as one of shutdown listeners.
+type AsyncWriter struct {
+	w      io.Writer
+	bw     *bufio.Writer
+	policy OverflowPolicy
+
+	queue   chan []byte
+	flushc  chan chan error
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	// pending is the number of logs in bw which are not yet written into w, and err is the
+	// last error from w which is not yet returned by Sync. Both are only accessed by run.
+	pending uint64
+	err     error
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncWriter create AsyncWriter which writes into w, and start its background goroutine.
+func NewAsyncWriter(w io.Writer, config AsyncWriterConfig) *AsyncWriter {
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1024
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 4096
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	aw := &AsyncWriter{
+		w:      w,
+		bw:     bufio.NewWriterSize(w, config.BufferSize),
+		policy: config.OverflowPolicy,
+		queue:  make(chan []byte, config.QueueSize),
+		flushc: make(chan chan error),
+		done:   make(chan struct{}),
+	}
+	go aw.run(config.FlushInterval)
+	return aw
+}
+
+// Write copy p into the queue, the queue is handled according to OverflowPolicy when it's full.
+// Write never return error from underlying writer, use Sync to get it. Logs which fail to be
+// written into underlying writer are discarded and counted by Dropped.
+func (aw *AsyncWriter) Write(p []byte) (n int, err error) {
+	aw.mu.RLock()
+	defer aw.mu.RUnlock()
+	if aw.closed {
+		return 0, ErrAsyncWriterClosed
+	}
+
+	b := make([]byte, len(p))
+	copy(b, p)
+	switch aw.policy {
+	case DropNewestOnOverflow:
+		select {
+		case aw.queue <- b:
+		default:
+			aw.dropped.Add(1)
+		}
+	case DropOldestOnOverflow:
+		for {
+			select {
+			case aw.queue <- b:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-aw.queue:
+				aw.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		aw.queue <- b
+	}
+	return len(p), nil
+}
+
+// Dropped return the number of logs dropped due to OverflowPolicy or failed writes into
+// underlying writer.
+func (aw *AsyncWriter) Dropped() uint64 {
+	return aw.dropped.Load()
+}
+
+// Sync writes all pending logs and flush the buffer into underlying writer, it also calls
+// underlying writer Sync method if available.
+func (aw *AsyncWriter) Sync() error {
+	aw.mu.RLock()
+	defer aw.mu.RUnlock()
+	if aw.closed {
+		return nil
+	}
+
+	errc := make(chan error, 1)
+	aw.flushc <- errc
+	return <-errc
+}
+
+// Close writes all pending logs, flush the buffer and stop the background goroutine, subsequent
+// Write will return ErrAsyncWriterClosed. Close doesn't close nor sync underlying writer.
+func (aw *AsyncWriter) Close() error {
+	aw.mu.Lock()
+	if aw.closed {
+		aw.mu.Unlock()
+		return nil
+	}
+	aw.closed = true
+	close(aw.queue)
+	aw.mu.Unlock()
+
+	<-aw.done
+	return aw.sync()
+}
+
+// OnShutdown calls Close and wait until it completes or ctx is done.
+func (aw *AsyncWriter) OnShutdown(ctx context.Context) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- aw.Close()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (aw *AsyncWriter) run(interval time.Duration) {
+	defer close(aw.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case b, ok := <-aw.queue:
+			if !ok {
+				return
+			}
+			aw.write(b)
+		case <-ticker.C:
+			aw.flush()
+		case errc := <-aw.flushc:
+			aw.drain()
+			errc <- aw.sync()
+		}
+	}
+}
+
+// drain writes all logs currently in the queue.
+func (aw *AsyncWriter) drain() {
+	for {
+		select {
+		case b, ok := <-aw.queue:
+			if !ok {
+				return
+			}
+			aw.write(b)
+		default:
+			return
+		}
+	}
+}
+
+// write writes b into the buffer, which may flush the buffer into underlying writer.
+func (aw *AsyncWriter) write(b []byte) {
+	aw.pending++
+	if _, err := aw.bw.Write(b); err != nil {
+		aw.discard(err)
+	}
+}
+
+// flush writes the buffer into underlying writer.
+func (aw *AsyncWriter) flush() {
+	if err := aw.bw.Flush(); err != nil {
+		aw.discard(err)
+		return
+	}
+	aw.pending = 0
+}
+
+// discard counts pending logs as dropped and reset the buffer, since bufio.Writer keeps
+// returning its first error and never writes subsequent logs otherwise.
+func (aw *AsyncWriter) discard(err error) {
+	aw.dropped.Add(aw.pending)
+	aw.pending = 0
+	aw.bw.Reset(aw.w)
+	aw.err = err
+}
+
+// sync flush the buffer and calls underlying writer Sync method, it return and clear the
+// last error from underlying writer.
+func (aw *AsyncWriter) sync() error {
+	aw.flush()
+	err := aw.err
+	aw.err = nil
+	if err != nil {
+		return err
+	}
+	if s, ok := aw.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriter blocks every Write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// failingWriter fails every Write while fail is true.
+type failingWriter struct {
+	fail atomic.Bool
+	buf  bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail.Load() {
+		return 0, errors.New("broken pipe")
+	}
+	return w.buf.Write(p)
+}
+
+func TestAsyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, log.AsyncWriterConfig{QueueSize: 4})
+	logger := log.NewSimpleLogger(aw, log.InfoLogLevel)
+
+	for i := 0; i < 100; i++ {
+		logger.Info(fmt.Sprintf("Hello %d", i))
+	}
+	require.NoError(t, aw.Sync())
+	assert.Equal(t, 100, strings.Count(buf.String(), "\n"))
+
+	logger.Info("Bye")
+	require.NoError(t, aw.OnShutdown(context.Background()))
+	assert.Equal(t, 101, strings.Count(buf.String(), "\n"))
+	assert.Equal(t, uint64(0), aw.Dropped())
+
+	_, err := aw.Write([]byte("closed"))
+	assert.ErrorIs(t, err, log.ErrAsyncWriterClosed)
+}
+
+func TestAsyncWriter_DropNewest(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	aw := log.NewAsyncWriter(w, log.AsyncWriterConfig{
+		QueueSize:      2,
+		BufferSize:     1,
+		OverflowPolicy: log.DropNewestOnOverflow,
+	})
+
+	for i := 0; i < 10; i++ {
+		aw.Write([]byte(fmt.Sprintf("%d\n", i)))
+	}
+	close(w.release)
+	require.NoError(t, aw.Close())
+	// at most 1 log is being written while 2 are in the queue
+	assert.GreaterOrEqual(t, aw.Dropped(), uint64(7))
+	assert.True(t, strings.HasPrefix(w.String(), "0\n"))
+}
+
+func TestAsyncWriter_DropOldest(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	aw := log.NewAsyncWriter(w, log.AsyncWriterConfig{
+		QueueSize:      2,
+		BufferSize:     1,
+		OverflowPolicy: log.DropOldestOnOverflow,
+	})
+
+	for i := 0; i < 10; i++ {
+		aw.Write([]byte(fmt.Sprintf("%d\n", i)))
+	}
+	close(w.release)
+	require.NoError(t, aw.Close())
+	assert.GreaterOrEqual(t, aw.Dropped(), uint64(7))
+	assert.True(t, strings.HasSuffix(w.String(), "8\n9\n"))
+}
+
+func TestAsyncWriter_FlushInterval(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	close(w.release)
+	aw := log.NewAsyncWriter(w, log.AsyncWriterConfig{FlushInterval: 10 * time.Millisecond})
+	defer aw.Close()
+
+	aw.Write([]byte("Hello\n"))
+	assert.Eventually(t, func() bool {
+		return w.String() == "Hello\n"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAsyncWriter_WriteError(t *testing.T) {
+	w := &failingWriter{}
+	w.fail.Store(true)
+	aw := log.NewAsyncWriter(w, log.AsyncWriterConfig{})
+	defer aw.Close()
+
+	aw.Write([]byte("Hello 1\n"))
+	aw.Write([]byte("Hello 2\n"))
+	assert.EqualError(t, aw.Sync(), "broken pipe")
+	assert.Equal(t, uint64(2), aw.Dropped())
+
+	w.fail.Store(false)
+	aw.Write([]byte("Hello 3\n"))
+	require.NoError(t, aw.Sync())
+	assert.Equal(t, "Hello 3\n", w.buf.String())
+	assert.Equal(t, uint64(2), aw.Dropped())
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"context"
+	"sync"
+)
+
+type loggerContextKey struct{}
+
+// ContextWithLogger return copy of ctx carrying logger, ie. to let middleware stash logger
+// enriched with request fields so downstream code can retrieve it using FromContext.
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext return Logger carried by ctx which is stored using ContextWithLogger, or global
+// logger when ctx doesn't carry any.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok {
+			return logger
+		}
+	}
+	return GetLogger()
+}
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID return copy of ctx carrying request id, which is written as "requestId"
+// field by RequestIDExtractor.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext return request id stored in ctx using ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok
+}
+
+// RequestIDExtractor extract request id stored using ContextWithRequestID as "requestId" field.
+func RequestIDExtractor(ctx context.Context) []LogField {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return []LogField{String("requestId", id)}
+	}
+	return nil
+}
+
+// ContextExtractor extract fields from context, logger drivers use registered extractors
+// to enrich logs written by logger returned from WithContext, ie. to add request id or
+// current user id to every log. OpenTelemetry span information is extracted by
+// log/otelctx module which keeps this module free from OpenTelemetry dependency.
+type ContextExtractor func(ctx context.Context) []LogField
+
+var (
+	extractorsMu      sync.RWMutex
+	contextExtractors = []ContextExtractor{RequestIDExtractor}
+)
+
+// AddContextExtractor register extractors to be used by all logger drivers, extractors
+// are called in the same order they are registered. By default only RequestIDExtractor
+// is registered.
+func AddContextExtractor(extractors ...ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	contextExtractors = append(contextExtractors[:len(contextExtractors):len(contextExtractors)], extractors...)
+}
+
+// SetContextExtractors replace all registered extractors, calling it without argument
+// will remove all extractors including the default RequestIDExtractor.
+func SetContextExtractors(extractors ...ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	contextExtractors = append([]ContextExtractor(nil), extractors...)
+}
+
+// FieldsFromContext return fields extracted from ctx using all registered extractors.
+func FieldsFromContext(ctx context.Context) []LogField {
+	return AppendContextFields(nil, ctx)
+}
+
+// AppendContextFields append fields extracted from ctx using all registered extractors
+// into fields, this is useful for logger driver to avoid allocating new slice.
+func AppendContextFields(fields []LogField, ctx context.Context) []LogField {
+	if ctx == nil {
+		return fields
+	}
+	extractorsMu.RLock()
+	extractors := contextExtractors
+	extractorsMu.RUnlock()
+
+	for _, extract := range extractors {
+		fields = append(fields, extract(ctx)...)
+	}
+	return fields
+}
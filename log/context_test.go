@@ -0,0 +1,63 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestIdKey struct{}
+
+func TestSimpleLogger_WithContext(t *testing.T) {
+	ctx := log.ContextWithRequestID(context.Background(), "abc")
+
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+	writeLog(logger.WithContext(ctx), log.InfoLogLevel, log.Field("v1", "value1"))
+
+	require.Equal(t, 1, len(observer.entries))
+	assert.Equal(t, "level:info\tmessage:Hello\tv1:value1\trequestId:abc\n", observer.entries[0][39:])
+}
+
+func TestAddContextExtractor(t *testing.T) {
+	defer log.SetContextExtractors(log.RequestIDExtractor)
+
+	log.SetContextExtractors()
+	log.AddContextExtractor(func(ctx context.Context) []log.LogField {
+		if id, ok := ctx.Value(requestIdKey{}).(string); ok {
+			return []log.LogField{log.String("requestId", id)}
+		}
+		return nil
+	})
+	ctx := context.WithValue(context.Background(), requestIdKey{}, "abc")
+
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+	writeLog(logger.WithContext(ctx), log.InfoLogLevel)
+	writeLog(logger.WithContext(context.Background()), log.InfoLogLevel)
+
+	require.Equal(t, 2, len(observer.entries))
+	assert.Equal(t, "level:info\tmessage:Hello\trequestId:abc\n", observer.entries[0][39:])
+	assert.Equal(t, "level:info\tmessage:Hello\n", observer.entries[1][39:])
+}
+
+func TestFromContext(t *testing.T) {
+	global := logtest.SetGlobal(t)
+	assert.Same(t, global, log.FromContext(context.Background()))
+	assert.Same(t, global, log.FromContext(nil)) //nolint:staticcheck
+
+	ctx := log.ContextWithLogger(context.Background(), log.With(log.String("requestId", "abc")))
+	log.FromContext(ctx).Info("Hello")
+	log.WithContext(ctx).Info("Hello from context")
+	log.WithContext(context.Background()).Info("Hello from global")
+
+	require.Equal(t, 3, global.Len())
+	entries := global.Entries()
+	assert.Equal(t, "abc", entries[0].FieldValue("requestId"))
+	assert.Equal(t, "abc", entries[1].FieldValue("requestId"))
+	assert.Nil(t, entries[2].FieldValue("requestId"))
+}
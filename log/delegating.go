@@ -0,0 +1,93 @@
+package log
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+var _ Logger = &delegatingLogger{}
+
+// delegatingLogger writes into the current global logger. Loggers derived using With,
+// Named or WithContext record the derivation and replay it against the global logger,
+// the result is cached until the global logger is replaced.
+type delegatingLogger struct {
+	parent *delegatingLogger
+	derive func(Logger) Logger
+
+	cache atomic.Pointer[delegatedLogger]
+}
+
+type delegatedLogger struct {
+	global *loggerHolder
+	logger Logger
+}
+
+var rootDelegatingLogger = &delegatingLogger{}
+
+// Delegating return Logger which always writes into the current global logger, so
+// packages which hold Logger captured early, ie. during init, still follow later
+// SetLogger calls. Loggers derived from it using With, Named or WithContext are also
+// delegating.
+func Delegating() Logger {
+	return rootDelegatingLogger
+}
+
+// resolve return the logger this delegatingLogger currently delegates to.
+func (d *delegatingLogger) resolve() Logger {
+	global := globalLogger.Load()
+	if d.parent == nil {
+		return global.logger
+	}
+	if c := d.cache.Load(); c != nil && c.global == global {
+		return c.logger
+	}
+	logger := d.derive(d.parent.resolve())
+	d.cache.Store(&delegatedLogger{global: global, logger: logger})
+	return logger
+}
+
+func (d *delegatingLogger) Fatal(msg string, err error, optfields ...LogField) {
+	d.resolve().Fatal(msg, err, optfields...)
+}
+
+func (d *delegatingLogger) Panic(msg string, err error, optfields ...LogField) {
+	d.resolve().Panic(msg, err, optfields...)
+}
+
+func (d *delegatingLogger) Error(msg string, err error, optfields ...LogField) {
+	d.resolve().Error(msg, err, optfields...)
+}
+
+func (d *delegatingLogger) Warn(msg string, optfields ...LogField) {
+	d.resolve().Warn(msg, optfields...)
+}
+
+func (d *delegatingLogger) Info(msg string, optfields ...LogField) {
+	d.resolve().Info(msg, optfields...)
+}
+
+func (d *delegatingLogger) Debug(msg string, optfields ...LogField) {
+	d.resolve().Debug(msg, optfields...)
+}
+
+func (d *delegatingLogger) Trace(msg string, optfields ...LogField) {
+	d.resolve().Trace(msg, optfields...)
+}
+
+func (d *delegatingLogger) WithContext(ctx context.Context) Logger {
+	return &delegatingLogger{parent: d, derive: func(l Logger) Logger {
+		return l.WithContext(ctx)
+	}}
+}
+
+func (d *delegatingLogger) With(fields ...LogField) Logger {
+	return &delegatingLogger{parent: d, derive: func(l Logger) Logger {
+		return l.With(fields...)
+	}}
+}
+
+func (d *delegatingLogger) Named(name string) Logger {
+	return &delegatingLogger{parent: d, derive: func(l Logger) Logger {
+		return l.Named(name)
+	}}
+}
@@ -0,0 +1,72 @@
+package log_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegating(t *testing.T) {
+	defer log.SetLogger(log.GetLogger())
+
+	logger := log.Delegating()
+	named := logger.Named("payments").With(log.String("requestId", "abc"))
+
+	first := &logObserver{}
+	setGlobalLogger(first)
+	logger.Info("Hello")
+	named.Info("Hello")
+	require.Equal(t, 2, len(first.entries))
+	assert.Equal(t, "level:info\tmessage:Hello\n", first.entries[0][39:])
+	assert.Equal(t, "level:info\tlogger:payments\tmessage:Hello\trequestId:abc\n", first.entries[1][39:])
+
+	second := &logObserver{}
+	setGlobalLogger(second)
+	logger.Info("Hello")
+	named.Info("Hello")
+	assert.Equal(t, 2, len(first.entries))
+	require.Equal(t, 2, len(second.entries))
+	assert.Equal(t, "level:info\tlogger:payments\tmessage:Hello\trequestId:abc\n", second.entries[1][39:])
+}
+
+func TestSetLogger_Delegating(t *testing.T) {
+	defer log.SetLogger(log.GetLogger())
+
+	observer := &logObserver{}
+	setGlobalLogger(observer)
+
+	// setting delegating logger as global logger must not make it delegates to itself
+	log.SetLogger(log.Delegating())
+	log.Info("Hello")
+	log.SetLogger(log.Delegating().Named("payments"))
+	log.Info("Hello")
+	require.Equal(t, 2, len(observer.entries))
+	assert.Equal(t, "level:info\tlogger:payments\tmessage:Hello\n", observer.entries[1][39:])
+}
+
+func TestSetLogger_Concurrent(t *testing.T) {
+	defer log.SetLogger(log.GetLogger())
+
+	logger := log.Delegating().With(log.String("requestId", "abc"))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.SetLogger(&log.NoOpLogger{})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.Info("Hello")
+				logger.Info("Hello")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,31 @@
+package otellog
+
+import (
+	"github.com/hexastack-dev/devkit-go/log"
+	apilog "go.opentelemetry.io/otel/log"
+)
+
+// DefaultScope is the instrumentation scope name used when Config.Scope is empty.
+const DefaultScope = "github.com/hexastack-dev/devkit-go/log/drivers/otellog"
+
+// Config is configuration for OpenTelemetry logger.
+type Config struct {
+	// LoggerProvider is used to create OpenTelemetry logger, ie. sdk/log LoggerProvider
+	// configured with OTLP exporter. Default to global LoggerProvider, see
+	// go.opentelemetry.io/otel/log/global.
+	LoggerProvider apilog.LoggerProvider
+	// Scope is the instrumentation scope name of the created OpenTelemetry logger.
+	// Default to DefaultScope.
+	Scope string
+	// RootLogLevel define root log level to use, any log lower than this will not be emitted.
+	// Default to InfoLogLevel.
+	RootLogLevel log.LogLevel
+	// Redaction define policy to mask sensitive error and field values before they are
+	// emitted. Redaction is disabled when nil.
+	Redaction *log.RedactionPolicy
+	// ErrorDetails define which details of error passed to Error or Fatal are emitted
+	// per level as "error.stack" and "error.causes" attributes. No details are emitted when nil.
+	ErrorDetails log.ErrorDetails
+	// Hooks are called for every emitted log, global hooks are always called, see log.AddHook.
+	Hooks []log.Hook
+}
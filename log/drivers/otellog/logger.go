@@ -0,0 +1,300 @@
+package otellog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	apilog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+var (
+	_ log.Logger       = &Logger{}
+	_ log.LevelEnabler = &Logger{}
+)
+
+// Logger implements log.Logger which emits records using OpenTelemetry Logs API, thus
+// logs can be exported to OTLP collector along with traces. Records emitted by logger
+// returned from WithContext are correlated with the span in the context by the SDK.
+//
+// Fields extracted by registered log.ContextExtractor are also emitted as attributes,
+// except "traceId", "spanId" and "traceFlags" written by otelctx.TraceContextExtractor
+// since the record already carries span information.
+type Logger struct {
+	logger     apilog.Logger
+	ctx        context.Context
+	level      *log.AtomicLevel
+	name       string
+	fields     []log.LogField
+	attrs      []apilog.KeyValue
+	redactor   *log.Redactor
+	errdetails log.ErrorDetails
+	hooks      []log.Hook
+}
+
+// New create Logger using config. Register LoggerProvider ForceFlush as log.ExitHandler
+// to flush pending records when the program exits due to Fatal log.
+func New(config Config) *Logger {
+	if config.LoggerProvider == nil {
+		config.LoggerProvider = global.GetLoggerProvider()
+	}
+	if config.Scope == "" {
+		config.Scope = DefaultScope
+	}
+	l := &Logger{
+		logger:     config.LoggerProvider.Logger(config.Scope),
+		level:      log.NewAtomicLevel(config.RootLogLevel),
+		errdetails: config.ErrorDetails,
+		hooks:      config.Hooks,
+	}
+	if config.Redaction != nil {
+		l.redactor = log.NewRedactor(*config.Redaction)
+	}
+	return l
+}
+
+// Fatal emits record at FatalLogLevel, then calls log.Exit(1).
+func (l *Logger) Fatal(msg string, err error, optfields ...log.LogField) {
+	l.emit(log.FatalLogLevel, msg, err, optfields)
+	log.Exit(1)
+}
+
+// Panic emits record at PanicLogLevel, then panics with msg.
+func (l *Logger) Panic(msg string, err error, optfields ...log.LogField) {
+	l.emit(log.PanicLogLevel, msg, err, optfields)
+	panic(msg)
+}
+
+func (l *Logger) Error(msg string, err error, optfields ...log.LogField) {
+	l.emit(log.ErrorLogLevel, msg, err, optfields)
+}
+
+func (l *Logger) Warn(msg string, optfields ...log.LogField) {
+	l.emit(log.WarnLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Info(msg string, optfields ...log.LogField) {
+	l.emit(log.InfoLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Debug(msg string, optfields ...log.LogField) {
+	l.emit(log.DebugLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Trace(msg string, optfields ...log.LogField) {
+	l.emit(log.TraceLogLevel, msg, nil, optfields)
+}
+
+// WithContext return Logger instance which emits records with ctx, the SDK use the span
+// in ctx to set trace id, span id and trace flags of the records.
+func (l *Logger) WithContext(ctx context.Context) log.Logger {
+	l2 := *l
+	l2.ctx = ctx
+	return &l2
+}
+
+// With return Logger instance which emits passed fields as attributes of every record.
+func (l *Logger) With(fields ...log.LogField) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	fields = l.redactor.RedactFields(fields)
+	l2 := *l
+	l2.fields = make([]log.LogField, 0, len(l.fields)+len(fields))
+	l2.fields = append(l2.fields, l.fields...)
+	l2.fields = append(l2.fields, fields...)
+	l2.attrs = make([]apilog.KeyValue, 0, len(l.attrs)+len(fields))
+	l2.attrs = append(l2.attrs, l.attrs...)
+	l2.attrs = appendFields(l2.attrs, fields)
+	return &l2
+}
+
+// Named return Logger instance with passed name appended to current logger name, the name
+// is emitted in "logger" attribute.
+func (l *Logger) Named(name string) log.Logger {
+	l2 := *l
+	switch {
+	case l.name == "":
+		l2.name = name
+	case name != "":
+		l2.name = l.name + "." + name
+	}
+	return &l2
+}
+
+// Enabled return true if log at lv is enabled by root log level.
+func (l *Logger) Enabled(lv log.LogLevel) bool {
+	return l.level.Enabled(lv)
+}
+
+// Level return AtomicLevel which control root log level of Logger and all loggers derived from it.
+func (l *Logger) Level() *log.AtomicLevel {
+	return l.level
+}
+
+func (l *Logger) emit(lv log.LogLevel, msg string, err error, optfields []log.LogField) {
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.level.Enabled(lv) {
+		return
+	}
+	var r apilog.Record
+	r.SetSeverity(toSeverity(lv))
+	if !l.logger.Enabled(ctx, r) {
+		return
+	}
+
+	now := time.Now()
+	r.SetTimestamp(now)
+	r.SetSeverityText(severityText(lv))
+	r.SetBody(apilog.StringValue(msg))
+	if l.name != "" {
+		r.AddAttributes(apilog.String("logger", l.name))
+	}
+	err = l.redactor.RedactError(err)
+	if err != nil {
+		r.AddAttributes(
+			apilog.String("exception.type", fmt.Sprintf("%T", err)),
+			apilog.String("exception.message", err.Error()),
+		)
+	}
+	errfields := l.redactor.RedactFields(l.errdetails.AppendFields(nil, lv, err))
+	optfields = l.redactor.RedactFields(optfields)
+	var ctxfields []log.LogField
+	if l.ctx != nil {
+		ctxfields = l.redactor.RedactFields(skipTraceFields(log.FieldsFromContext(l.ctx)))
+	}
+	r.AddAttributes(appendFields(nil, errfields)...)
+	r.AddAttributes(l.attrs...)
+	r.AddAttributes(appendFields(nil, optfields)...)
+	r.AddAttributes(appendFields(nil, ctxfields)...)
+	l.logger.Emit(ctx, r)
+
+	log.RunHooks(l.hooks, func() log.HookEntry {
+		fields := make([]log.LogField, 0, len(errfields)+len(l.fields)+len(optfields)+len(ctxfields))
+		fields = append(fields, errfields...)
+		fields = append(fields, l.fields...)
+		fields = append(fields, optfields...)
+		fields = append(fields, ctxfields...)
+		return log.HookEntry{
+			Time:    now,
+			Level:   lv,
+			Name:    l.name,
+			Message: msg,
+			Err:     err,
+			Fields:  fields,
+			Context: l.ctx,
+		}
+	})
+}
+
+// skipTraceFields remove span information fields written by otelctx.TraceContextExtractor.
+func skipTraceFields(fields []log.LogField) []log.LogField {
+	out := fields[:0]
+	for _, f := range fields {
+		switch f.Key {
+		case "traceId", "spanId", "traceFlags":
+		default:
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func toSeverity(lv log.LogLevel) apilog.Severity {
+	switch {
+	case lv >= log.FatalLogLevel:
+		return apilog.SeverityFatal
+	case lv >= log.PanicLogLevel:
+		// Panic is recoverable, it's reported as the most severe error instead of fatal.
+		return apilog.SeverityError4
+	case lv >= log.ErrorLogLevel:
+		return apilog.SeverityError
+	case lv >= log.WarnLogLevel:
+		return apilog.SeverityWarn
+	case lv >= log.InfoLogLevel:
+		return apilog.SeverityInfo
+	case lv >= log.DebugLogLevel:
+		return apilog.SeverityDebug
+	default:
+		return apilog.SeverityTrace
+	}
+}
+
+func severityText(lv log.LogLevel) string {
+	return strings.ToUpper(lv.String())
+}
+
+// appendFields convert fields into OpenTelemetry attributes and append them to attrs.
+func appendFields(attrs []apilog.KeyValue, fields []log.LogField) []apilog.KeyValue {
+	for _, field := range fields {
+		attrs = append(attrs, apilog.KeyValue{Key: field.Key, Value: convertValue(field)})
+	}
+	return attrs
+}
+
+func convertValue(field log.LogField) apilog.Value {
+	switch field.Kind {
+	case log.StringKind:
+		return apilog.StringValue(field.Str)
+	case log.Int64Kind:
+		return apilog.Int64Value(field.Int)
+	case log.BoolKind:
+		return apilog.BoolValue(field.Int == 1)
+	case log.DurationKind:
+		return apilog.StringValue(time.Duration(field.Int).String())
+	case log.TimeKind:
+		t, _ := field.Any().(time.Time)
+		return apilog.StringValue(t.Format(time.RFC3339Nano))
+	case log.ErrorKind, log.StringerKind:
+		return apilog.StringValue(fmt.Sprint(field.Any()))
+	default:
+		return anyValue(field.Value)
+	}
+}
+
+func anyValue(v any) apilog.Value {
+	switch v := v.(type) {
+	case nil:
+		return apilog.Value{}
+	case string:
+		return apilog.StringValue(v)
+	case bool:
+		return apilog.BoolValue(v)
+	case int:
+		return apilog.IntValue(v)
+	case int64:
+		return apilog.Int64Value(v)
+	case int32:
+		return apilog.Int64Value(int64(v))
+	case uint32:
+		return apilog.Int64Value(int64(v))
+	case float64:
+		return apilog.Float64Value(v)
+	case float32:
+		return apilog.Float64Value(float64(v))
+	case []byte:
+		return apilog.BytesValue(v)
+	case []string:
+		vs := make([]apilog.Value, len(v))
+		for i, s := range v {
+			vs[i] = apilog.StringValue(s)
+		}
+		return apilog.SliceValue(vs...)
+	case error:
+		return apilog.StringValue(v.Error())
+	case fmt.Stringer:
+		return apilog.StringValue(v.String())
+	default:
+		if b, err := json.Marshal(v); err == nil {
+			return apilog.StringValue(string(b))
+		}
+		return apilog.StringValue(fmt.Sprint(v))
+	}
+}
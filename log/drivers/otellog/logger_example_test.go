@@ -0,0 +1,33 @@
+package otellog_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/otellog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+func ExampleNew() {
+	// use OTLP exporter in production, ie. otlploggrpc.
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+	defer provider.Shutdown(context.Background())
+
+	// logger can be set as global logger using log.SetLogger, register provider.ForceFlush
+	// using log.AddExitHandler to flush pending records when the program exits due to Fatal log.
+	logger := otellog.New(otellog.Config{
+		LoggerProvider: provider,
+		RootLogLevel:   log.InfoLogLevel,
+	})
+	logger.Info("Hello", log.String("v1", "V1"))
+	logger.Debug("Hidden")
+
+	provider.ForceFlush(context.Background())
+	for _, r := range exporter.records {
+		fmt.Println(r.SeverityText(), r.Body().AsString())
+	}
+	// Output:
+	// INFO Hello
+}
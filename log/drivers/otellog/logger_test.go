@@ -0,0 +1,165 @@
+package otellog_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/otellog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apilog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// memoryExporter is in-memory sdklog.Exporter which keeps every exported record.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+func newLogger(config otellog.Config) (*otellog.Logger, *memoryExporter) {
+	exporter := &memoryExporter{}
+	config.LoggerProvider = sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	return otellog.New(config), exporter
+}
+
+func attributes(r sdklog.Record) map[string]apilog.Value {
+	attrs := make(map[string]apilog.Value)
+	r.WalkAttributes(func(kv apilog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestLogger(t *testing.T) {
+	logger, exporter := newLogger(otellog.Config{RootLogLevel: log.InfoLogLevel})
+
+	logger.Named("payments").With(log.String("requestId", "abc")).Error("Something went wrong", errors.New("oopsie"),
+		log.Int64("status", 500),
+		log.Bool("retry", false),
+		log.Object("http", map[string]string{"method": "GET"}),
+	)
+	logger.Debug("Hello")
+
+	require.Equal(t, 1, len(exporter.records))
+	r := exporter.records[0]
+	assert.Equal(t, apilog.SeverityError, r.Severity())
+	assert.Equal(t, "ERROR", r.SeverityText())
+	assert.Equal(t, "Something went wrong", r.Body().AsString())
+	assert.Equal(t, otellog.DefaultScope, r.InstrumentationScope().Name)
+	assert.False(t, r.Timestamp().IsZero())
+
+	attrs := attributes(r)
+	assert.Equal(t, "payments", attrs["logger"].AsString())
+	assert.Equal(t, "oopsie", attrs["exception.message"].AsString())
+	assert.Equal(t, "*errors.errorString", attrs["exception.type"].AsString())
+	assert.Equal(t, "abc", attrs["requestId"].AsString())
+	assert.Equal(t, int64(500), attrs["status"].AsInt64())
+	assert.Equal(t, false, attrs["retry"].AsBool())
+	assert.Equal(t, `{"method":"GET"}`, attrs["http"].AsString())
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	logger, exporter := newLogger(otellog.Config{RootLogLevel: log.InfoLogLevel})
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	logger.WithContext(ctx).Info("Hello")
+	require.Equal(t, 1, len(exporter.records))
+	r := exporter.records[0]
+	assert.Equal(t, span.SpanContext().TraceID(), r.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), r.SpanID())
+	assert.Equal(t, span.SpanContext().TraceFlags(), r.TraceFlags())
+}
+
+func TestLogger_WithContextTraceExtractor(t *testing.T) {
+	// register extractor writing the same fields as otelctx.TraceContextExtractor.
+	log.SetContextExtractors(log.RequestIDExtractor, func(ctx context.Context) []log.LogField {
+		return []log.LogField{log.String("traceId", "abc"), log.String("spanId", "def")}
+	})
+	t.Cleanup(func() {
+		log.SetContextExtractors(log.RequestIDExtractor)
+	})
+	logger, exporter := newLogger(otellog.Config{RootLogLevel: log.InfoLogLevel})
+
+	logger.WithContext(log.ContextWithRequestID(context.Background(), "123")).Info("Hello")
+	require.Equal(t, 1, len(exporter.records))
+	attrs := attributes(exporter.records[0])
+	assert.Equal(t, "123", attrs["requestId"].AsString())
+	assert.NotContains(t, attrs, "traceId")
+	assert.NotContains(t, attrs, "spanId")
+}
+
+func TestLogger_Level(t *testing.T) {
+	logger, exporter := newLogger(otellog.Config{RootLogLevel: log.WarnLogLevel})
+	logger.Info("Hello")
+	assert.Equal(t, 0, len(exporter.records))
+	assert.False(t, logger.Enabled(log.InfoLogLevel))
+
+	logger.Level().SetLevel(log.DebugLogLevel)
+	logger.Named("payments").Debug("Hello")
+	assert.Equal(t, 1, len(exporter.records))
+}
+
+func TestLogger_Severity(t *testing.T) {
+	logger, exporter := newLogger(otellog.Config{RootLogLevel: log.TraceLogLevel})
+	logger.Trace("Hello")
+	assert.PanicsWithValue(t, "Something went wrong", func() {
+		logger.Panic("Something went wrong", errors.New("oopsie"))
+	})
+
+	require.Equal(t, 2, len(exporter.records))
+	assert.Equal(t, apilog.SeverityTrace, exporter.records[0].Severity())
+	assert.Equal(t, "TRACE", exporter.records[0].SeverityText())
+	assert.Equal(t, apilog.SeverityError4, exporter.records[1].Severity())
+	assert.Equal(t, "PANIC", exporter.records[1].SeverityText())
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	var code int
+	log.SetExitFunc(func(c int) { code = c })
+	t.Cleanup(func() {
+		log.SetExitFunc(nil)
+	})
+
+	logger, exporter := newLogger(otellog.Config{})
+	logger.Fatal("Something went wrong", errors.New("oopsie"))
+	require.Equal(t, 1, len(exporter.records))
+	assert.Equal(t, apilog.SeverityFatal, exporter.records[0].Severity())
+	assert.Equal(t, 1, code)
+}
+
+func TestLogger_Redaction(t *testing.T) {
+	logger, exporter := newLogger(otellog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Redaction:    &log.RedactionPolicy{Keys: []string{"authorization"}},
+	})
+
+	logger.Info("Request", log.Object("http", map[string]any{"header": map[string]string{"Authorization": "Bearer x"}}))
+	require.Equal(t, 1, len(exporter.records))
+	attrs := attributes(exporter.records[0])
+	assert.Equal(t, `{"header":{"Authorization":"[REDACTED]"}}`, attrs["http"].AsString())
+}
@@ -0,0 +1,76 @@
+package zaplog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wrapper is a log.Logger implementation wrapping another log.Logger.
+type wrapper struct {
+	log.Logger
+}
+
+func (w wrapper) Info(msg string, fields ...log.LogField) {
+	w.Logger.Info(msg, fields...)
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var m map[string]any
+		require.NoError(t, dec.Decode(&m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestLogger_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf}).Info("Hello")
+	wrapper{zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf, CallerSkip: 1})}.Info("Hello")
+	zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf, DisableCaller: true}).Info("Hello")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0]["caller"], "zaplog/caller_test.go:37")
+	assert.Contains(t, lines[1]["caller"], "zaplog/caller_test.go:38")
+	assert.NotContains(t, lines[2], "caller")
+}
+
+func TestLogger_Stacktrace(t *testing.T) {
+	var buf bytes.Buffer
+	lv := log.ErrorLogLevel
+	logger := zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf, StacktraceLevel: &lv})
+	logger.Warn("Hello")
+	logger.Error("Hello", nil)
+	zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf}).Error("Hello", nil)
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 3)
+	assert.NotContains(t, lines[0], "stacktrace")
+	require.Contains(t, lines[1], "stacktrace")
+	assert.Contains(t, lines[1]["stacktrace"], "zaplog_test.TestLogger_Stacktrace")
+	assert.NotContains(t, lines[1]["stacktrace"], "zaplog.(*Logger).Error")
+	assert.NotContains(t, lines[2], "stacktrace")
+}
+
+func TestLogger_SlogCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{Encoder: zaplog.JSONEncoder, Output: &buf})
+	slogger := slog.New(log.NewSlogHandler(logger))
+	slogger.Info("Hello", "status", 200)
+	slogger.Debug("Hello")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0]["caller"], "zaplog/caller_test.go:69")
+	assert.Equal(t, float64(200), lines[0]["status"])
+}
@@ -1,7 +1,11 @@
 package zaplog
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/hexastack-dev/devkit-go/log"
 )
@@ -13,6 +17,40 @@ const (
 	ConsoleEncoder
 )
 
+// String return lowercase name of the encoder, ie. "json".
+func (e Encoder) String() string {
+	switch e {
+	case JSONEncoder:
+		return "json"
+	case ConsoleEncoder:
+		return "console"
+	default:
+		return fmt.Sprintf("Encoder(%d)", e)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, it return error for unknown encoder.
+func (e Encoder) MarshalText() ([]byte, error) {
+	if e > ConsoleEncoder {
+		return nil, fmt.Errorf("unknown encoder: %d", e)
+	}
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler which allows Encoder to be read from
+// JSON or YAML configuration, accepted names are "json" and "console".
+func (e *Encoder) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "json":
+		*e = JSONEncoder
+	case "console":
+		*e = ConsoleEncoder
+	default:
+		return fmt.Errorf("unrecognized format: %q", text)
+	}
+	return nil
+}
+
 // FileLogConfig define configurations for rolling file log.
 type FileLogConfig struct {
 	// Enabled wether to log to a file or not.
@@ -28,6 +66,21 @@ type FileLogConfig struct {
 	MaxSize int
 	// MaxBackups is the maximum number of old log files to retain. The default is to retain all old log files.
 	MaxBackups int
+	// MaxAge is the maximum number of days to retain old log files based on the timestamp encoded
+	// in their filename. The default is not to remove old log files based on age.
+	MaxAge int
+	// Compress determines if the rotated log files should be compressed using gzip.
+	Compress bool
+	// LocalTime determines if local time is used for the timestamp in backup filenames and
+	// for time-based rotation. Default to UTC.
+	LocalTime bool
+	// Rotation define time-based rotation interval, the file is also rotated when it reaches
+	// MaxSize. Default to NoRotation.
+	Rotation Rotation
+	// ReopenSignals reopens the file whenever one of the signals is received, ie. syscall.SIGHUP
+	// to work with logrotate-style tooling which moves the file then sends the signal.
+	// See Logger.Reopen to reopen the file programmatically.
+	ReopenSignals []os.Signal
 }
 
 // Config is configuration for log. Any underlying log framework should comply with this spec.
@@ -37,10 +90,53 @@ type Config struct {
 	// RootLogLevel define root log level to use, any log lower than this will not be logged.
 	// Default to InfoLogLevel.
 	RootLogLevel log.LogLevel
+	// LoggerLevels overrides RootLogLevel for named loggers (see log.Logger.Named) which
+	// name matches the prefix, ie. {"payments": log.DebugLogLevel}. Overrides only
+	// applies to console log and sinks, file log always use FileLogConfig.LogLevel.
+	LoggerLevels log.LevelOverrides
 	// Encoder to use to log default to ConsoleEncoder.
 	Encoder Encoder
+	// EncoderConfig customise keys and encodings of console log, file log and all sinks,
+	// ie. to match Elastic Common Schema using ECSPreset.
+	EncoderConfig EncoderConfig
 	// FileLogConfig configuration for rolling file log.
 	FileLogConfig FileLogConfig
 	// Output set log output for console. Defaults to os.Stdout.
 	Output io.Writer
+	// Sampling enables zap's sampler to cap the number of logs with the same level and
+	// message written per tick for both console and file log. Sampling is disabled when nil.
+	Sampling *log.SamplerConfig
+	// Redaction define policy to mask sensitive error and field values before they are
+	// written into console and file log. Redaction is disabled when nil.
+	Redaction *log.RedactionPolicy
+	// ErrorDetails define which details of error passed to Error or Fatal are rendered
+	// per level as "error.stack" and "error.causes" fields. No details are rendered when nil.
+	ErrorDetails log.ErrorDetails
+	// DisableCaller stops annotating logs with file name and line number of the caller.
+	DisableCaller bool
+	// CallerSkip increases the number of frames skipped to find the caller, ie. 1 when Logger
+	// is wrapped by another log.Logger implementation so the caller of the wrapper is written.
+	// It's also applied to stack trace.
+	CallerSkip int
+	// StacktraceLevel attaches stack trace of the caller to logs at or above the level, ie.
+	// log.ErrorLogLevel. Stack trace is disabled when nil.
+	StacktraceLevel *log.LogLevel
+	// Sinks replaces console log configured by Output and Encoder with the list of outputs,
+	// each with its own encoder, minimum level and field filter. RootLogLevel and LoggerLevels
+	// apply to all sinks. File log configured by FileLogConfig is written in addition to the sinks.
+	Sinks []Sink
+	// Hooks are called for every log written into console or file log after the log
+	// passes level filtering and sampling, global hooks are always called, see log.AddHook.
+	Hooks []log.Hook
+}
+
+// validate return error describing every misconfigured sink.
+func (c Config) validate() error {
+	var errs []error
+	for _, sink := range c.Sinks {
+		if err := sink.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
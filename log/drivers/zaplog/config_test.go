@@ -0,0 +1,31 @@
+package zaplog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_UnmarshalJSON(t *testing.T) {
+	var config zaplog.Config
+	err := json.Unmarshal([]byte(`{
+		"RootLogLevel": "warning",
+		"LoggerLevels": {"payments": "debug"},
+		"Encoder": "console",
+		"FileLogConfig": {"Enabled": true, "LogLevel": "err", "Filename": "app.log", "Rotation": "daily", "MaxAge": 7}
+	}`), &config)
+	require.NoError(t, err)
+	assert.Equal(t, log.WarnLogLevel, config.RootLogLevel)
+	assert.Equal(t, log.LevelOverrides{"payments": log.DebugLogLevel}, config.LoggerLevels)
+	assert.Equal(t, zaplog.ConsoleEncoder, config.Encoder)
+	assert.Equal(t, log.ErrorLogLevel, config.FileLogConfig.LogLevel)
+	assert.Equal(t, zaplog.JSONEncoder, config.FileLogConfig.Encoder)
+	assert.Equal(t, zaplog.RotateDaily, config.FileLogConfig.Rotation)
+	assert.Equal(t, 7, config.FileLogConfig.MaxAge)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"Encoder": "xml"}`), &config))
+}
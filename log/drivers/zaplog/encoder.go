@@ -0,0 +1,295 @@
+package zaplog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// EncoderPreset is a set of keys and encodings matching structured logging schema.
+type EncoderPreset string
+
+const (
+	// DefaultPreset writes "timestamp" in ISO8601, lowercase "level", "logger", "caller",
+	// "stacktrace" and "message" keys.
+	DefaultPreset EncoderPreset = ""
+	// ECSPreset follows Elastic Common Schema, it writes "@timestamp", "log.level", "log.logger",
+	// "log.origin.file.name", "log.origin.file.line", "error.message", "error.stack_trace",
+	// "message" and "ecs.version" keys. Trace context fields are written as "trace.id" and "span.id".
+	ECSPreset EncoderPreset = "ecs"
+	// GCPPreset follows Google Cloud Logging structured logging, it writes "time" in RFC3339Nano,
+	// "severity" using Cloud Logging severity names, "logger", "caller", "stack_trace" and
+	// "message" keys. Trace context fields are written as "logging.googleapis.com/trace",
+	// "logging.googleapis.com/spanId" and "logging.googleapis.com/trace_sampled".
+	GCPPreset EncoderPreset = "gcp"
+)
+
+// TimeEncoding define how log time is encoded.
+type TimeEncoding string
+
+const (
+	// ISO8601Time encodes time as ISO8601 string with millisecond precision, ie. "2006-01-02T15:04:05.000Z0700".
+	ISO8601Time TimeEncoding = "iso8601"
+	// RFC3339NanoTime encodes time as RFC3339 string with nanosecond precision.
+	RFC3339NanoTime TimeEncoding = "rfc3339nano"
+	// EpochMillisTime encodes time as milliseconds since Unix epoch.
+	EpochMillisTime TimeEncoding = "epochmillis"
+	// EpochNanosTime encodes time as nanoseconds since Unix epoch.
+	EpochNanosTime TimeEncoding = "epochnanos"
+)
+
+// LevelCase define casing of encoded level.
+type LevelCase string
+
+const (
+	// LowercaseLevel encodes level in lowercase, ie. "info".
+	LowercaseLevel LevelCase = "lower"
+	// UppercaseLevel encodes level in uppercase, ie. "INFO".
+	UppercaseLevel LevelCase = "upper"
+)
+
+// omitKey is used in EncoderConfig to omit the key from log output.
+const omitKey = "-"
+
+// EncoderConfig customise keys and encodings used by JSONEncoder and ConsoleEncoder, empty
+// value use the value from Preset. Use "-" as key to omit it from log output.
+type EncoderConfig struct {
+	// Preset define base keys and encodings. Default to DefaultPreset.
+	Preset EncoderPreset
+	// TimeKey is the key of log time.
+	TimeKey string
+	// LevelKey is the key of log level.
+	LevelKey string
+	// NameKey is the key of logger name.
+	NameKey string
+	// CallerKey is the key of log caller.
+	CallerKey string
+	// StacktraceKey is the key of stack trace, written at or above Config.StacktraceLevel.
+	StacktraceKey string
+	// MessageKey is the key of log message.
+	MessageKey string
+	// TimeEncoding define how log time is encoded.
+	TimeEncoding TimeEncoding
+	// LevelCase define casing of encoded level, it's ignored by GCPPreset which use
+	// Cloud Logging severity names.
+	LevelCase LevelCase
+	// ProjectID is Google Cloud project id used by GCPPreset to write trace id as
+	// "projects/[ProjectID]/traces/[TraceID]". Default to GOOGLE_CLOUD_PROJECT environment
+	// variable, trace id is written as is when both are empty.
+	ProjectID string
+}
+
+// encoderSpec is zapcore.EncoderConfig along with preset specific behaviours.
+type encoderSpec struct {
+	zapcore.EncoderConfig
+	// fields are written in every log.
+	fields []zap.Field
+	// mapContext rewrites fields extracted from context.
+	mapContext func(fields []log.LogField) []log.LogField
+	// wrapEncoder wraps every encoder built from the spec.
+	wrapEncoder func(enc zapcore.Encoder) zapcore.Encoder
+}
+
+// newEncoder create JSON or console encoder using the spec.
+func (spec encoderSpec) newEncoder(encoder Encoder) zapcore.Encoder {
+	var enc zapcore.Encoder
+	switch encoder {
+	case ConsoleEncoder:
+		enc = zapcore.NewConsoleEncoder(spec.EncoderConfig)
+	default:
+		enc = zapcore.NewJSONEncoder(spec.EncoderConfig)
+	}
+	if spec.wrapEncoder != nil {
+		enc = spec.wrapEncoder(enc)
+	}
+	return enc
+}
+
+func (c EncoderConfig) build() encoderSpec {
+	spec := encoderSpec{EncoderConfig: zap.NewProductionEncoderConfig()}
+	spec.TimeKey = "timestamp"
+	spec.MessageKey = "message"
+	timeEncoding, levelCase := ISO8601Time, LowercaseLevel
+
+	switch c.Preset {
+	case ECSPreset:
+		spec.TimeKey = "@timestamp"
+		spec.LevelKey = "log.level"
+		spec.NameKey = "log.logger"
+		spec.CallerKey = "log.origin.file.name"
+		spec.StacktraceKey = "error.stack_trace"
+		spec.fields = []zap.Field{zap.String("ecs.version", "1.6.0")}
+		spec.mapContext = renameTraceFields
+	case GCPPreset:
+		spec.TimeKey = "time"
+		spec.LevelKey = "severity"
+		spec.StacktraceKey = "stack_trace"
+		timeEncoding = RFC3339NanoTime
+		projectID := c.ProjectID
+		if projectID == "" {
+			projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		}
+		spec.mapContext = gcpTraceFields(projectID)
+	}
+
+	setKey(&spec.TimeKey, c.TimeKey)
+	setKey(&spec.LevelKey, c.LevelKey)
+	setKey(&spec.NameKey, c.NameKey)
+	setKey(&spec.CallerKey, c.CallerKey)
+	setKey(&spec.StacktraceKey, c.StacktraceKey)
+	setKey(&spec.MessageKey, c.MessageKey)
+	if c.Preset == ECSPreset {
+		spec.EncodeCaller = encodeCallerFile
+		withLine := spec.CallerKey != zapcore.OmitKey
+		spec.wrapEncoder = func(enc zapcore.Encoder) zapcore.Encoder {
+			return &ecsEncoder{Encoder: enc, withLine: withLine}
+		}
+	}
+	if c.TimeEncoding != "" {
+		timeEncoding = c.TimeEncoding
+	}
+	if c.LevelCase != "" {
+		levelCase = c.LevelCase
+	}
+
+	switch timeEncoding {
+	case RFC3339NanoTime:
+		spec.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	case EpochMillisTime:
+		spec.EncodeTime = zapcore.EpochMillisTimeEncoder
+	case EpochNanosTime:
+		spec.EncodeTime = zapcore.EpochNanosTimeEncoder
+	default:
+		spec.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+	switch {
+	case c.Preset == GCPPreset:
+		spec.EncodeLevel = encodeSeverity
+	case levelCase == UppercaseLevel:
+		spec.EncodeLevel = encodeUppercaseLevel
+	default:
+		spec.EncodeLevel = encodeLevel
+	}
+	return spec
+}
+
+func setKey(dst *string, key string) {
+	switch key {
+	case "":
+	case omitKey:
+		*dst = zapcore.OmitKey
+	default:
+		*dst = key
+	}
+}
+
+// encodeLevel encodes TraceLevel as "trace" and the rest using zapcore.LowercaseLevelEncoder.
+func encodeLevel(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if lvl == TraceLevel {
+		enc.AppendString("trace")
+		return
+	}
+	zapcore.LowercaseLevelEncoder(lvl, enc)
+}
+
+// encodeUppercaseLevel encodes TraceLevel as "TRACE" and the rest using zapcore.CapitalLevelEncoder.
+func encodeUppercaseLevel(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if lvl == TraceLevel {
+		enc.AppendString("TRACE")
+		return
+	}
+	zapcore.CapitalLevelEncoder(lvl, enc)
+}
+
+// encodeSeverity encodes level using Google Cloud Logging severity names.
+func encodeSeverity(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch {
+	case lvl >= zapcore.FatalLevel:
+		enc.AppendString("ALERT")
+	case lvl >= zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case lvl >= zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case lvl >= zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case lvl >= zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	default:
+		enc.AppendString("DEBUG")
+	}
+}
+
+// encodeCallerFile encodes caller file without the line number, ie. "zaplog/logger.go".
+func encodeCallerFile(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+	file := caller.TrimmedPath()
+	if i := strings.LastIndexByte(file, ':'); i >= 0 {
+		file = file[:i]
+	}
+	enc.AppendString(file)
+}
+
+// ecsEncoder writes caller line as "log.origin.file.line" and error passed to Error, Panic
+// or Fatal as "error.message", the keys zap can't write using EncoderConfig.
+type ecsEncoder struct {
+	zapcore.Encoder
+	withLine bool
+}
+
+func (e *ecsEncoder) Clone() zapcore.Encoder {
+	return &ecsEncoder{Encoder: e.Encoder.Clone(), withLine: e.withLine}
+}
+
+func (e *ecsEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	ecsfields := make([]zapcore.Field, 0, len(fields)+1)
+	for _, f := range fields {
+		if f.Type == zapcore.ErrorType && f.Key == "error" {
+			// fmt formats typed-nil error as "<nil>" instead of panicking.
+			f = zap.String("error.message", fmt.Sprint(f.Interface))
+		}
+		ecsfields = append(ecsfields, f)
+	}
+	if e.withLine && ent.Caller.Defined {
+		ecsfields = append(ecsfields, zap.Int("log.origin.file.line", ent.Caller.Line))
+	}
+	return e.Encoder.EncodeEntry(ent, ecsfields)
+}
+
+// renameTraceFields renames fields written by traceFields into ECS keys.
+func renameTraceFields(fields []log.LogField) []log.LogField {
+	for i, f := range fields {
+		switch f.Key {
+		case "traceId":
+			fields[i].Key = "trace.id"
+		case "spanId":
+			fields[i].Key = "span.id"
+		}
+	}
+	return fields
+}
+
+// gcpTraceFields rewrites fields written by traceFields into special fields
+// recognized by Google Cloud Logging to correlate logs with traces.
+func gcpTraceFields(projectID string) func(fields []log.LogField) []log.LogField {
+	return func(fields []log.LogField) []log.LogField {
+		for i, f := range fields {
+			switch f.Key {
+			case "traceId":
+				trace := f.Str
+				if projectID != "" {
+					trace = "projects/" + projectID + "/traces/" + trace
+				}
+				fields[i] = log.String("logging.googleapis.com/trace", trace)
+			case "spanId":
+				fields[i].Key = "logging.googleapis.com/spanId"
+			case "traceFlags":
+				fields[i] = log.Bool("logging.googleapis.com/trace_sampled", f.Int&1 == 1)
+			}
+		}
+		return fields
+	}
+}
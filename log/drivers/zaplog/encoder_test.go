@@ -0,0 +1,99 @@
+package zaplog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func writeJSONLog(t *testing.T, config zaplog.EncoderConfig, fn func(logger log.Logger)) map[string]any {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Encoder:       zaplog.JSONEncoder,
+		EncoderConfig: config,
+		Output:        &buf,
+	})
+	fn(logger.Named("payments"))
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	return m
+}
+
+func TestEncoderConfig_Default(t *testing.T) {
+	m := writeJSONLog(t, zaplog.EncoderConfig{}, func(logger log.Logger) {
+		logger.Info("Hello")
+	})
+	assert.Contains(t, m, "timestamp")
+	assert.Contains(t, m, "caller")
+	assert.Equal(t, "info", m["level"])
+	assert.Equal(t, "payments", m["logger"])
+	assert.Equal(t, "Hello", m["message"])
+}
+
+func TestEncoderConfig_Keys(t *testing.T) {
+	m := writeJSONLog(t, zaplog.EncoderConfig{
+		TimeKey:      "ts",
+		LevelKey:     "lvl",
+		NameKey:      "-",
+		CallerKey:    "-",
+		MessageKey:   "msg",
+		TimeEncoding: zaplog.EpochMillisTime,
+		LevelCase:    zaplog.UppercaseLevel,
+	}, func(logger log.Logger) {
+		logger.Warn("Hello")
+	})
+	assert.Equal(t, map[string]any{"ts": m["ts"], "lvl": "WARN", "msg": "Hello"}, m)
+	assert.IsType(t, float64(0), m["ts"])
+}
+
+func TestEncoderConfig_ECSPreset(t *testing.T) {
+	ctx, span := trace.NewTracerProvider().Tracer("").Start(context.Background(), "testECS")
+	defer span.End()
+
+	m := writeJSONLog(t, zaplog.EncoderConfig{Preset: zaplog.ECSPreset}, func(logger log.Logger) {
+		logger.WithContext(ctx).Info("Hello")
+	})
+	assert.Contains(t, m, "@timestamp")
+	assert.Equal(t, "zaplog/encoder_test.go", m["log.origin.file.name"])
+	assert.IsType(t, float64(0), m["log.origin.file.line"])
+	assert.Equal(t, "info", m["log.level"])
+	assert.Equal(t, "payments", m["log.logger"])
+	assert.Equal(t, "Hello", m["message"])
+	assert.Equal(t, "1.6.0", m["ecs.version"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), m["trace.id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), m["span.id"])
+}
+
+func TestEncoderConfig_ECSPresetError(t *testing.T) {
+	m := writeJSONLog(t, zaplog.EncoderConfig{Preset: zaplog.ECSPreset}, func(logger log.Logger) {
+		logger.Error("Failed", errors.New("oopsie"))
+	})
+	assert.Equal(t, "error", m["log.level"])
+	assert.Equal(t, "oopsie", m["error.message"])
+	assert.NotContains(t, m, "error")
+}
+
+func TestEncoderConfig_GCPPreset(t *testing.T) {
+	ctx, span := trace.NewTracerProvider().Tracer("").Start(context.Background(), "testGCP")
+	defer span.End()
+
+	m := writeJSONLog(t, zaplog.EncoderConfig{Preset: zaplog.GCPPreset, ProjectID: "my-project"}, func(logger log.Logger) {
+		logger.WithContext(ctx).Warn("Hello")
+	})
+	assert.Contains(t, m, "time")
+	assert.Equal(t, "WARNING", m["severity"])
+	assert.Equal(t, "Hello", m["message"])
+	assert.Equal(t, "projects/my-project/traces/"+span.SpanContext().TraceID().String(), m["logging.googleapis.com/trace"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), m["logging.googleapis.com/spanId"])
+	assert.Equal(t, true, m["logging.googleapis.com/trace_sampled"])
+	assert.NotContains(t, m, "traceId")
+}
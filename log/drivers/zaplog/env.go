@@ -0,0 +1,106 @@
+package zaplog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hexastack-dev/devkit-go/log"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	// EnvLogLevel define root log level, ie. "debug", see log.ParseLogLevel. Default to info.
+	EnvLogLevel = "LOG_LEVEL"
+	// EnvLogFormat define console encoder, either "json" or "console". Default to json.
+	EnvLogFormat = "LOG_FORMAT"
+	// EnvLogFile define file to write logs to, file log is enabled when it's set. File log
+	// use the root log level and JSON encoder.
+	EnvLogFile = "LOG_FILE"
+	// EnvLogFileMaxSize define maximum size in megabytes of the log file before it gets rotated,
+	// it requires EnvLogFile to be set.
+	EnvLogFileMaxSize = "LOG_FILE_MAX_SIZE"
+	// EnvLogLevels define per logger level overrides as comma separated name=level pairs,
+	// ie. "payments=debug,http.*=warn", see Config.LoggerLevels.
+	EnvLogLevels = "LOG_LEVELS"
+)
+
+// ConfigFromEnv create Config from environment variables, see EnvLogLevel, EnvLogFormat,
+// EnvLogFile, EnvLogFileMaxSize and EnvLogLevels. Unset or empty variables leave the
+// default value of Config, invalid values are reported in the returned error instead of
+// falling back to the default.
+func ConfigFromEnv() (Config, error) {
+	var config Config
+	var errs []error
+
+	if v := os.Getenv(EnvLogLevel); v != "" {
+		lv, err := log.ParseLogLevel(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", EnvLogLevel, err))
+		}
+		config.RootLogLevel = lv
+	}
+	if v := os.Getenv(EnvLogFormat); v != "" {
+		if err := config.Encoder.UnmarshalText([]byte(v)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", EnvLogFormat, err))
+		}
+	}
+	if v := os.Getenv(EnvLogFile); v != "" {
+		config.FileLogConfig = FileLogConfig{
+			Enabled:  true,
+			LogLevel: config.RootLogLevel,
+			Encoder:  JSONEncoder,
+			Filename: v,
+		}
+	}
+	if v := os.Getenv(EnvLogFileMaxSize); v != "" {
+		size, err := strconv.Atoi(v)
+		switch {
+		case err != nil || size <= 0:
+			errs = append(errs, fmt.Errorf("invalid %s: %q is not a positive number of megabytes", EnvLogFileMaxSize, v))
+		case !config.FileLogConfig.Enabled:
+			errs = append(errs, fmt.Errorf("%s requires %s", EnvLogFileMaxSize, EnvLogFile))
+		}
+		config.FileLogConfig.MaxSize = size
+	}
+	if v := os.Getenv(EnvLogLevels); v != "" {
+		overrides, err := parseLevelOverrides(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", EnvLogLevels, err))
+		}
+		config.LoggerLevels = overrides
+	}
+	return config, errors.Join(errs...)
+}
+
+// NewFromEnv create Logger using Config created by ConfigFromEnv, see NewLogger.
+func NewFromEnv() (*Logger, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewLogger(config)
+}
+
+func parseLevelOverrides(s string) (log.LevelOverrides, error) {
+	overrides := log.LevelOverrides{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected name=level but got %q", pair)
+		}
+		lv, err := log.ParseLogLevel(strings.TrimSpace(level))
+		if err != nil {
+			return nil, fmt.Errorf("logger %q: %w", name, err)
+		}
+		overrides[name] = lv
+	}
+	return overrides, nil
+}
@@ -0,0 +1,72 @@
+package zaplog_test
+
+import (
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(zaplog.EnvLogLevel, "DEBUG")
+	t.Setenv(zaplog.EnvLogFormat, "console")
+	t.Setenv(zaplog.EnvLogFile, "./log/app.log")
+	t.Setenv(zaplog.EnvLogFileMaxSize, "10")
+	t.Setenv(zaplog.EnvLogLevels, "payments=warn, http.*=error")
+
+	config, err := zaplog.ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, log.DebugLogLevel, config.RootLogLevel)
+	assert.Equal(t, zaplog.ConsoleEncoder, config.Encoder)
+	assert.Equal(t, zaplog.FileLogConfig{
+		Enabled:  true,
+		LogLevel: log.DebugLogLevel,
+		Encoder:  zaplog.JSONEncoder,
+		Filename: "./log/app.log",
+		MaxSize:  10,
+	}, config.FileLogConfig)
+	assert.Equal(t, log.LevelOverrides{
+		"payments": log.WarnLogLevel,
+		"http.*":   log.ErrorLogLevel,
+	}, config.LoggerLevels)
+}
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	for _, key := range []string{zaplog.EnvLogLevel, zaplog.EnvLogFormat, zaplog.EnvLogFile, zaplog.EnvLogFileMaxSize, zaplog.EnvLogLevels} {
+		t.Setenv(key, "")
+	}
+
+	config, err := zaplog.ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, zaplog.Config{}, config)
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	t.Setenv(zaplog.EnvLogLevel, "verbose")
+	t.Setenv(zaplog.EnvLogFormat, "xml")
+	t.Setenv(zaplog.EnvLogFile, "")
+	t.Setenv(zaplog.EnvLogFileMaxSize, "10MB")
+	t.Setenv(zaplog.EnvLogLevels, "payments")
+
+	_, err := zaplog.ConfigFromEnv()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid LOG_LEVEL: unrecognized level: "verbose"`)
+	assert.Contains(t, err.Error(), `invalid LOG_FORMAT: unrecognized format: "xml"`)
+	assert.Contains(t, err.Error(), `invalid LOG_FILE_MAX_SIZE: "10MB" is not a positive number of megabytes`)
+	assert.Contains(t, err.Error(), `invalid LOG_LEVELS: expected name=level but got "payments"`)
+
+	t.Setenv(zaplog.EnvLogLevel, "")
+	t.Setenv(zaplog.EnvLogFormat, "")
+	t.Setenv(zaplog.EnvLogFileMaxSize, "10")
+	t.Setenv(zaplog.EnvLogLevels, "")
+	_, err = zaplog.ConfigFromEnv()
+	assert.EqualError(t, err, "LOG_FILE_MAX_SIZE requires LOG_FILE")
+
+	t.Setenv(zaplog.EnvLogFileMaxSize, "")
+	t.Setenv(zaplog.EnvLogLevels, "payments=loud")
+	logger, err := zaplog.NewFromEnv()
+	assert.Nil(t, logger)
+	assert.EqualError(t, err, `invalid LOG_LEVELS: logger "payments": unrecognized level: "loud"`)
+}
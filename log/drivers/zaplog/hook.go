@@ -0,0 +1,133 @@
+package zaplog
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// contextFieldKey is the key of zap.Skip field carrying the context passed to WithContext,
+// the field is ignored by encoders and only used by hookCore to set log.HookEntry.Context.
+const contextFieldKey = "zaplog.context"
+
+func contextField(ctx context.Context) zapcore.Field {
+	return zapcore.Field{Key: contextFieldKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// hookCore wraps zapcore.Core to call log.Hook for every entry written by the wrapped core.
+type hookCore struct {
+	zapcore.Core
+	hooks  []log.Hook
+	fields []zapcore.Field
+}
+
+func newHookCore(core zapcore.Core, hooks []log.Hook) zapcore.Core {
+	return &hookCore{Core: core, hooks: hooks}
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	c2 := &hookCore{
+		Core:  c.Core.With(fields),
+		hooks: c.hooks,
+	}
+	c2.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	c2.fields = append(c2.fields, c.fields...)
+	c2.fields = append(c2.fields, fields...)
+	return c2
+}
+
+// Check adds hookCore only when the wrapped core will write the entry, hookCore is the
+// outermost core thus ce is nil unless the wrapped core adds itself.
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ce = c.Core.Check(ent, ce); ce != nil {
+		ce = ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write only calls hooks, the entry is written by the wrapped core added in Check.
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	log.RunHooks(c.hooks, func() log.HookEntry {
+		e := log.HookEntry{
+			Time:    ent.Time,
+			Level:   fromZapLevel(ent.Level),
+			Name:    ent.LoggerName,
+			Message: ent.Message,
+			Fields:  make([]log.LogField, 0, len(c.fields)+len(fields)),
+		}
+		e.Fields = appendLogFields(e.Fields, c.fields, &e)
+		e.Fields = appendLogFields(e.Fields, fields, &e)
+		return e
+	})
+	return nil
+}
+
+func fromZapLevel(lvl zapcore.Level) log.LogLevel {
+	switch {
+	case lvl >= zapcore.FatalLevel:
+		return log.FatalLogLevel
+	case lvl >= zapcore.DPanicLevel:
+		return log.PanicLogLevel
+	case lvl >= zapcore.ErrorLevel:
+		return log.ErrorLogLevel
+	case lvl >= zapcore.WarnLevel:
+		return log.WarnLogLevel
+	case lvl >= zapcore.InfoLevel:
+		return log.InfoLogLevel
+	case lvl >= zapcore.DebugLevel:
+		return log.DebugLogLevel
+	default:
+		return log.TraceLogLevel
+	}
+}
+
+// appendLogFields convert zap fields into log fields and append them to fields, "error"
+// field is put in e.Err instead, context field is put in e.Context and zap.Skip fields are ignored.
+func appendLogFields(fields []log.LogField, zfields []zapcore.Field, e *log.HookEntry) []log.LogField {
+	for _, f := range zfields {
+		switch f.Type {
+		case zapcore.SkipType:
+			if ctx, ok := f.Interface.(context.Context); ok && f.Key == contextFieldKey {
+				e.Context = ctx
+			}
+		case zapcore.ErrorType:
+			err, _ := f.Interface.(error)
+			if f.Key == "error" {
+				e.Err = err
+				continue
+			}
+			fields = append(fields, log.Err(f.Key, err))
+		case zapcore.StringType:
+			fields = append(fields, log.String(f.Key, f.String))
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+			fields = append(fields, log.Int64(f.Key, f.Integer))
+		case zapcore.BoolType:
+			fields = append(fields, log.Bool(f.Key, f.Integer == 1))
+		case zapcore.DurationType:
+			fields = append(fields, log.Duration(f.Key, time.Duration(f.Integer)))
+		case zapcore.TimeType:
+			t := time.Unix(0, f.Integer)
+			if loc, ok := f.Interface.(*time.Location); ok {
+				t = t.In(loc)
+			}
+			fields = append(fields, log.Time(f.Key, t))
+		case zapcore.StringerType:
+			if v, ok := f.Interface.(fmt.Stringer); ok {
+				fields = append(fields, log.Stringer(f.Key, v))
+			}
+		case zapcore.Float64Type:
+			fields = append(fields, log.Field(f.Key, math.Float64frombits(uint64(f.Integer))))
+		default:
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+			for k, v := range enc.Fields {
+				fields = append(fields, log.Field(k, v))
+			}
+		}
+	}
+	return fields
+}
@@ -2,203 +2,549 @@ package zaplog
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	// "github.com/uptrace/opentelemetry-go-extra/otelzap"
 	"os"
-	"path/filepath"
-
-	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"time"
 
 	"github.com/hexastack-dev/devkit-go/log"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// New create new instance of Logger.
+// TraceLevel is zapcore.Level used to write log at log.TraceLogLevel, since zap doesn't define
+// level lower than DebugLevel. Logger created using NewDefaultLogger encodes it as "trace".
+const TraceLevel = zapcore.DebugLevel - 1
+
+// New create new instance of Logger, zapLogger core is wrapped to call global hooks
+// for every written log, see log.AddHook.
 func New(zapLogger *zap.Logger) *Logger {
 	return &Logger{
-		zlog: zapLogger,
+		zlog: zapLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newHookCore(core, nil)
+		})),
 	}
 }
 
+// NewLogger create Logger using config like NewDefaultLogger, but return an error instead
+// of panicking when config is invalid, ie. a sink with unknown Output.
+func NewLogger(config Config) (*Logger, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return NewDefaultLogger(config), nil
+}
+
 // NewDefaultLogger create new instance of Zap Logger using
-// default configuration.
+// default configuration. It panics when config is invalid, use NewLogger to
+// get an error instead.
 func NewDefaultLogger(config Config) *Logger {
 	if config.Output == nil {
 		config.Output = os.Stdout
 	}
-	return &Logger{
-		zlog: configureZap(config),
+	level := log.NewAtomicLevel(config.RootLogLevel)
+	spec := config.EncoderConfig.build()
+	l := &Logger{level: level, mapContext: spec.mapContext}
+	l.zlog, l.files, l.conns = configureZap(config, spec, level)
+	if config.Redaction != nil {
+		l.redactor = log.NewRedactor(*config.Redaction)
 	}
+	l.errdetails = config.ErrorDetails
+	return l
 }
 
-func configureZap(config Config) *zap.Logger {
-	conf := zap.NewProductionEncoderConfig()
-	conf.TimeKey = "timestamp"
-	conf.EncodeTime = zapcore.ISO8601TimeEncoder
-	conf.MessageKey = "message"
-
-	outputs := configureOutputs(config, conf)
+func configureZap(config Config, spec encoderSpec, level *log.AtomicLevel) (*zap.Logger, []*rollingWriter, []*netWriter) {
+	outputs, files, conns := configureOutputs(config, spec, level)
 	core := zapcore.NewTee(outputs...)
-	return zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+	if config.Sampling != nil {
+		core = newSampler(core, *config.Sampling)
+	}
+	core = newHookCore(core, config.Hooks)
+	// caller skip is also used to skip frames of stack trace, thus it's added regardless of DisableCaller.
+	opts := []zap.Option{zap.AddCallerSkip(1 + config.CallerSkip), zap.WithFatalHook(exitHook{core})}
+	if !config.DisableCaller {
+		opts = append(opts, zap.AddCaller())
+	}
+	if config.StacktraceLevel != nil {
+		opts = append(opts, zap.AddStacktrace(toZapLevel(*config.StacktraceLevel)))
+	}
+	zlog := zap.New(core, opts...)
+	if len(spec.fields) > 0 {
+		zlog = zlog.With(spec.fields...)
+	}
+	return zlog, files, conns
+}
+
+// exitHook syncs all outputs then calls log.Exit after Fatal log is written, this allows
+// Fatal to be tested using log.SetExitFunc and runs registered log.ExitHandler.
+type exitHook struct {
+	core zapcore.Core
 }
 
-func configureOutputs(config Config, enconfig zapcore.EncoderConfig) []zapcore.Core {
-	cores := []zapcore.Core{
-		zapcore.NewCore(
-			buildZapEncoder(config.Encoder, enconfig),
-			zapcore.AddSync(config.Output),
-			mapLogLevel(config.RootLogLevel)),
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	_ = h.core.Sync()
+	log.Exit(1)
+}
+
+// configureOutputs build a core for every sink, console log is used as the only sink when
+// config.Sinks is empty. File log core is added when FileLogConfig is enabled, it doesn't
+// use root log level nor overrides. Rolling files and network connections used by the cores
+// are returned to allow them to be rotated, reopened or closed.
+func configureOutputs(config Config, spec encoderSpec, level *log.AtomicLevel) ([]zapcore.Core, []*rollingWriter, []*netWriter) {
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{Writer: config.Output, Encoder: config.Encoder, LogLevel: log.TraceLogLevel}}
+	}
+	var files []*rollingWriter
+	var conns []*netWriter
+	cores := make([]zapcore.Core, 0, len(sinks)+1)
+	for _, sink := range sinks {
+		core, file, conn := sink.build(spec, sinkLevelEnabler(level, config.LoggerLevels, sink.LogLevel))
+		if file != nil {
+			files = append(files, file)
+		}
+		if conn != nil {
+			conns = append(conns, conn)
+		}
+		if len(config.LoggerLevels) > 0 {
+			core = &overridesCore{
+				Core:      core,
+				root:      level,
+				overrides: config.LoggerLevels,
+			}
+		}
+		cores = append(cores, core)
 	}
 	if config.FileLogConfig.Enabled {
+		file := newRollingWriter(config.FileLogConfig)
+		files = append(files, file)
 		cores = append(cores, zapcore.NewCore(
-			buildZapEncoder(config.FileLogConfig.Encoder, enconfig),
-			zapcore.AddSync(rollingFile(config)),
+			spec.newEncoder(config.FileLogConfig.Encoder),
+			zapcore.AddSync(file),
 			mapLogLevel(config.FileLogConfig.LogLevel)),
 		)
 	}
-	return cores
+	return cores, files, conns
 }
 
-func buildZapEncoder(encoder Encoder, enconfig zapcore.EncoderConfig) zapcore.Encoder {
-	switch encoder {
-	case ConsoleEncoder:
-		return zapcore.NewConsoleEncoder(enconfig)
-	default:
-		return zapcore.NewJSONEncoder(enconfig)
+func newSampler(core zapcore.Core, config log.SamplerConfig) zapcore.Core {
+	tick := config.Tick
+	if tick <= 0 {
+		tick = time.Second
 	}
+	return zapcore.NewSamplerWithOptions(core, tick, config.Initial, config.Thereafter)
 }
 
 func mapLogLevel(level log.LogLevel) zap.AtomicLevel {
+	return zap.NewAtomicLevelAt(toZapLevel(level))
+}
+
+func toZapLevel(level log.LogLevel) zapcore.Level {
 	switch level {
 	case log.FatalLogLevel:
-		return zap.NewAtomicLevelAt(zap.FatalLevel)
+		return zap.FatalLevel
+	case log.PanicLogLevel:
+		return zap.PanicLevel
 	case log.ErrorLogLevel:
-		return zap.NewAtomicLevelAt(zap.ErrorLevel)
+		return zap.ErrorLevel
 	case log.WarnLogLevel:
-		return zap.NewAtomicLevelAt(zap.WarnLevel)
+		return zap.WarnLevel
 	case log.DebugLogLevel:
-		return zap.NewAtomicLevelAt(zap.DebugLevel)
+		return zap.DebugLevel
+	case log.TraceLogLevel:
+		return TraceLevel
 	default:
-		return zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel
 	}
 }
 
-// rollingFile create lumberjack.Logger instance, will return nil if
-// config.FilelogEnabled is false, and will panic if the log path
-// cannot be resolved.
-func rollingFile(config Config) *lumberjack.Logger {
-	path := config.FileLogConfig.Filename
-	if path == "" {
-		path = filepath.Base(os.Args[0]) + ".log"
+// rootLevelEnabler enables zap level at or above current root level, which allows root level
+// to be changed at runtime. When overrides is not empty, it also enables the lowest level of
+// all overrides, thus overridesCore should be used to filter the entries.
+func rootLevelEnabler(root *log.AtomicLevel, overrides log.LevelOverrides) zapcore.LevelEnabler {
+	if len(overrides) == 0 {
+		return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return lvl >= toZapLevel(root.Level())
+		})
 	}
-	path, err := filepath.Abs(path)
-	if err != nil {
-		panic(err)
+	min := toZapLevel(overrides.Min(log.FatalLogLevel))
+	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= min || lvl >= toZapLevel(root.Level())
+	})
+}
+
+// overridesCore filter entries using log level resolved from the entry's logger name,
+// the underlying core should enable the lowest level of root and all overrides.
+type overridesCore struct {
+	zapcore.Core
+	root      *log.AtomicLevel
+	overrides log.LevelOverrides
+}
+
+func (c *overridesCore) With(fields []zapcore.Field) zapcore.Core {
+	return &overridesCore{
+		Core:      c.Core.With(fields),
+		root:      c.root,
+		overrides: c.overrides,
 	}
-	return &lumberjack.Logger{
-		Filename:   path,
-		MaxBackups: config.FileLogConfig.MaxBackups,
-		MaxSize:    config.FileLogConfig.MaxSize,
+}
+
+func (c *overridesCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < toZapLevel(c.overrides.Level(ent.LoggerName, c.root.Level())) {
+		return ce
 	}
+	return c.Core.Check(ent, ce)
 }
 
-var _ log.Logger = &Logger{}
+var (
+	_ log.Logger       = &Logger{}
+	_ log.LevelEnabler = &Logger{}
+	_ log.RecordLogger = &Logger{}
+)
 
 type Logger struct {
-	zlog *zap.Logger
-	ctx  context.Context
+	zlog       *zap.Logger
+	ctx        context.Context
+	level      *log.AtomicLevel
+	redactor   *log.Redactor
+	errdetails log.ErrorDetails
+	files      []*rollingWriter
+	conns      []*netWriter
+	mapContext func(fields []log.LogField) []log.LogField
 	// otelog *otelzap.Logger
 }
 
-// Fatal logs a message at FatalLevel, then calls os.Exit(1). This should only be use with extra care, ideally Fatal
+// Fatal logs a message at FatalLevel, then calls log.Exit(1) when Logger is created using NewDefaultLogger,
+// otherwise the fatal hook configured in zap logger is used. This should only be use with extra care, ideally Fatal
 // should only be used in main where the apps encountered an error and have noway to continue.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
 func (l *Logger) Fatal(msg string, err error, optfields ...log.LogField) {
-	zfields := make([]zap.Field, 0, len(optfields))
-	zfields = append(zfields, zap.Error(err))
-	zfields = append(zfields, convertFields(optfields)...)
+	ce := l.zlog.Check(zap.FatalLevel, msg)
+	if ce == nil {
+		return
+	}
+	zfields := make([]zap.Field, 0, len(optfields)+1)
+	zfields = append(zfields, zap.Error(l.redactor.RedactError(err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(l.errdetails.AppendFields(nil, log.FatalLogLevel, err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
 	if l.ctx != nil {
-		zfields = append(zfields, fromContext(l.ctx)...)
-		// l.otelog.Ctx(l.ctx).Fatal(msg, zfields...)
-		// return
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
 	}
 
-	l.zlog.Fatal(msg, zfields...)
+	ce.Write(zfields...)
+}
+
+// Panic logs a message at PanicLevel, then panics with msg.
+// optfields is optional, when supplied it will be added as new field using
+// Key as field name, and Value as it's value.
+func (l *Logger) Panic(msg string, err error, optfields ...log.LogField) {
+	ce := l.zlog.Check(zap.PanicLevel, msg)
+	if ce == nil {
+		return
+	}
+	zfields := make([]zap.Field, 0, len(optfields)+1)
+	zfields = append(zfields, zap.Error(l.redactor.RedactError(err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(l.errdetails.AppendFields(nil, log.PanicLogLevel, err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
+	if l.ctx != nil {
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
+	}
+
+	ce.Write(zfields...)
 }
 
 // Error logs a message at ErrorLevel, put the passed error in "error" field.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
 func (l *Logger) Error(msg string, err error, optfields ...log.LogField) {
-	zfields := make([]zap.Field, 0, len(optfields))
-	zfields = append(zfields, zap.Error(err))
-	zfields = append(zfields, convertFields(optfields)...)
+	ce := l.zlog.Check(zap.ErrorLevel, msg)
+	if ce == nil {
+		return
+	}
+	zfields := make([]zap.Field, 0, len(optfields)+1)
+	zfields = append(zfields, zap.Error(l.redactor.RedactError(err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(l.errdetails.AppendFields(nil, log.ErrorLogLevel, err)))
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
 	if l.ctx != nil {
-		zfields = append(zfields, fromContext(l.ctx)...)
-		// l.otelog.Ctx(l.ctx).Error(msg, zfields...)
-		// return
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
 	}
 
-	l.zlog.Error(msg, zfields...)
+	ce.Write(zfields...)
 }
 
 // Warn logs a message at WarnLevel.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
 func (l *Logger) Warn(msg string, optfields ...log.LogField) {
+	ce := l.zlog.Check(zap.WarnLevel, msg)
+	if ce == nil {
+		return
+	}
 	zfields := make([]zap.Field, 0, len(optfields))
-	zfields = append(zfields, convertFields(optfields)...)
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
 	if l.ctx != nil {
-		zfields = append(zfields, fromContext(l.ctx)...)
-		// l.otelog.Ctx(l.ctx).Warn(msg, zfields...)
-		// return
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
 	}
 
-	l.zlog.Warn(msg, zfields...)
+	ce.Write(zfields...)
 }
 
 // Info logs a message at InfoLevel.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
 func (l *Logger) Info(msg string, optfields ...log.LogField) {
+	ce := l.zlog.Check(zap.InfoLevel, msg)
+	if ce == nil {
+		return
+	}
 	zfields := make([]zap.Field, 0, len(optfields))
-	zfields = append(zfields, convertFields(optfields)...)
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
 	if l.ctx != nil {
-		zfields = append(zfields, fromContext(l.ctx)...)
-		// l.otelog.Ctx(l.ctx).Info(msg, zfields...)
-		// return
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
 	}
 
-	l.zlog.Info(msg, zfields...)
+	ce.Write(zfields...)
 }
 
 // Debug logs a message at DebugLevel.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
 func (l *Logger) Debug(msg string, optfields ...log.LogField) {
+	ce := l.zlog.Check(zap.DebugLevel, msg)
+	if ce == nil {
+		return
+	}
 	zfields := make([]zap.Field, 0, len(optfields))
-	zfields = append(zfields, convertFields(optfields)...)
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
 	if l.ctx != nil {
-		zfields = append(zfields, fromContext(l.ctx)...)
-		// l.otelog.Ctx(l.ctx).Debug(msg, zfields...)
-		// return
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
 	}
 
-	l.zlog.Debug(msg, zfields...)
+	ce.Write(zfields...)
+}
+
+// Trace logs a message at TraceLevel.
+// optfields is optional, when supplied it will be added as new field using
+// Key as field name, and Value as it's value.
+func (l *Logger) Trace(msg string, optfields ...log.LogField) {
+	ce := l.zlog.Check(TraceLevel, msg)
+	if ce == nil {
+		return
+	}
+	zfields := make([]zap.Field, 0, len(optfields))
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
+	if l.ctx != nil {
+		zfields = appendFields(zfields, l.contextFields(l.ctx))
+		zfields = append(zfields, contextField(l.ctx))
+	}
+
+	ce.Write(zfields...)
+}
+
+// LogRecord implements log.RecordLogger, it writes log at lv using ctx, or the context passed
+// to WithContext when ctx is nil or empty context. The caller is resolved from pc when it's
+// not zero.
+func (l *Logger) LogRecord(ctx context.Context, pc uintptr, lv log.LogLevel, msg string, err error, optfields ...log.LogField) {
+	if lv > log.ErrorLogLevel {
+		lv = log.ErrorLogLevel
+	}
+	ce := l.zlog.Check(toZapLevel(lv), msg)
+	if ce == nil {
+		return
+	}
+	if pc != 0 && ce.Caller.Defined {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		ce.Caller = zapcore.EntryCaller{Defined: true, PC: pc, File: frame.File, Line: frame.Line, Function: frame.Function}
+	}
+	if ctx == nil || ctx == context.Background() || ctx == context.TODO() {
+		ctx = l.ctx
+	}
+	zfields := make([]zap.Field, 0, len(optfields)+1)
+	if lv == log.ErrorLogLevel {
+		zfields = append(zfields, zap.Error(l.redactor.RedactError(err)))
+		zfields = appendFields(zfields, l.redactor.RedactFields(l.errdetails.AppendFields(nil, lv, err)))
+	}
+	zfields = appendFields(zfields, l.redactor.RedactFields(optfields))
+	if ctx != nil {
+		zfields = appendFields(zfields, l.contextFields(ctx))
+		zfields = append(zfields, contextField(ctx))
+	}
+
+	ce.Write(zfields...)
 }
 
 // WithContext return Logger instance that will use passed context to log additional info,
-// such as opentelemetry's SpanID and TraceID if applicable.
+// such as opentelemetry's SpanID and TraceID if applicable. The fields are extracted using
+// registered log.ContextExtractor, see log.AddContextExtractor.
 func (l *Logger) WithContext(ctx context.Context) log.Logger {
 	return &Logger{
-		zlog: l.zlog,
-		ctx:  ctx,
+		zlog:       l.zlog,
+		ctx:        ctx,
+		level:      l.level,
+		redactor:   l.redactor,
+		errdetails: l.errdetails,
+		files:      l.files,
+		conns:      l.conns,
+		mapContext: l.mapContext,
 		// otelog: otelzap.New(l.zlog, otelzap.WithMinLevel(zapcore.InfoLevel)),
 	}
 }
 
+// With return Logger instance that will add passed fields to every log it writes,
+// it's equivalent to calling zap.Logger.With.
+func (l *Logger) With(fields ...log.LogField) log.Logger {
+	return &Logger{
+		zlog:       l.zlog.With(convertFields(l.redactor.RedactFields(fields))...),
+		ctx:        l.ctx,
+		level:      l.level,
+		redactor:   l.redactor,
+		errdetails: l.errdetails,
+		files:      l.files,
+		conns:      l.conns,
+		mapContext: l.mapContext,
+	}
+}
+
+// Named return Logger instance with passed name appended to current logger name,
+// it's equivalent to calling zap.Logger.Named.
+func (l *Logger) Named(name string) log.Logger {
+	return &Logger{
+		zlog:       l.zlog.Named(name),
+		ctx:        l.ctx,
+		level:      l.level,
+		redactor:   l.redactor,
+		errdetails: l.errdetails,
+		files:      l.files,
+		conns:      l.conns,
+		mapContext: l.mapContext,
+	}
+}
+
+// Enabled return true if log at lv is written by any output, level overrides of named
+// loggers are not considered.
+func (l *Logger) Enabled(lv log.LogLevel) bool {
+	return l.zlog.Core().Enabled(toZapLevel(lv))
+}
+
+// Level return AtomicLevel which control root log level of console output, changing the
+// level will affect all loggers derived from this Logger. Level return nil when Logger
+// is not created using NewDefaultLogger.
+func (l *Logger) Level() *log.AtomicLevel {
+	return l.level
+}
+
+// traceFields return OpenTelemetry's span information in ctx as "spanId", "traceId" and
+// "traceFlags" fields when the span is recording, the same fields written by otelctx.TraceContextExtractor.
+func traceFields(ctx context.Context) []log.LogField {
+	if ctx == nil {
+		return nil
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+	sc := span.SpanContext()
+	fields := make([]log.LogField, 0, 3)
+	if sc.HasSpanID() {
+		fields = append(fields, log.String("spanId", sc.SpanID().String()))
+	}
+	if sc.HasTraceID() {
+		fields = append(fields, log.String("traceId", sc.TraceID().String()))
+	}
+	fields = append(fields, log.Int64("traceFlags", int64(sc.TraceFlags())))
+	return fields
+}
+
+// contextFields return span information and fields extracted from ctx by registered
+// extractors, rewritten by encoder preset. Extracted fields with the same key as span
+// information are skipped, thus registering otelctx.TraceContextExtractor doesn't write
+// them twice.
+func (l *Logger) contextFields(ctx context.Context) []log.LogField {
+	fields := traceFields(ctx)
+	n := len(fields)
+	fields = log.AppendContextFields(fields, ctx)
+	if n > 0 {
+		fields = skipDuplicateFields(fields, n)
+	}
+	if l.mapContext != nil {
+		fields = l.mapContext(fields)
+	}
+	return l.redactor.RedactFields(fields)
+}
+
+// skipDuplicateFields remove fields after the first n fields which key is already used
+// by the first n fields.
+func skipDuplicateFields(fields []log.LogField, n int) []log.LogField {
+	out := fields[:n]
+	for _, f := range fields[n:] {
+		dup := false
+		for _, g := range fields[:n] {
+			if f.Key == g.Key {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Rotate rotates file log and files of FileOutput sinks immediately, the current files are
+// renamed as backup and new files are created.
+func (l *Logger) Rotate() error {
+	var errs []error
+	for _, file := range l.files {
+		if err := file.Rotate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reopen closes file log and files of FileOutput sinks, the files are reopened on the next
+// write and created if they have been moved, ie. by logrotate. See FileLogConfig.ReopenSignals
+// to reopen the files on signal.
+func (l *Logger) Reopen() error {
+	var errs []error
+	for _, file := range l.files {
+		if err := file.Reopen(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close syncs the logger then closes file log, files of FileOutput sinks and connections of
+// SyslogOutput, TCPOutput and UDPOutput sinks, it also stops reopening the files on
+// FileLogConfig.ReopenSignals. Close should be called when the logger is no longer used,
+// ie. when it's replaced by another logger, to release the files and connections.
+func (l *Logger) Close() error {
+	errs := []error{l.Sync()}
+	for _, file := range l.files {
+		if err := file.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, conn := range l.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Sync will calls zap logger Sync(), this method should be called
 // before the program exit.
 //
@@ -215,34 +561,57 @@ func (l *Logger) Sync() error {
 func (l *Logger) WithOptions(opts ...zap.Option) *Logger {
 	zlog := l.zlog.WithOptions(opts...)
 	return &Logger{
-		zlog: zlog,
-		ctx:  l.ctx,
+		zlog:       zlog,
+		ctx:        l.ctx,
+		level:      l.level,
+		redactor:   l.redactor,
+		errdetails: l.errdetails,
+		files:      l.files,
+		conns:      l.conns,
+		mapContext: l.mapContext,
 	}
 }
 
-func fromContext(ctx context.Context) []zap.Field {
-	otelFields := make([]zap.Field, 0)
-	span := trace.SpanFromContext(ctx)
-	if !span.IsRecording() {
-		return otelFields
-	}
-	sc := span.SpanContext()
-	if sc.HasSpanID() {
-		otelFields = append(otelFields, zap.String("spanId", sc.SpanID().String()))
-	}
-	if sc.HasTraceID() {
-		otelFields = append(otelFields, zap.String("traceId", sc.TraceID().String()))
-	}
-	otelFields = append(otelFields, zap.Int("traceFlags", int(sc.TraceFlags())))
-	return otelFields
-}
-
 func convertFields(fields []log.LogField) []zapcore.Field {
-	var zfields []zapcore.Field
+	return appendFields(make([]zapcore.Field, 0, len(fields)), fields)
+}
 
+// appendFields convert fields into zap fields and append them to zfields, typed fields
+// are converted to their zap counterparts to avoid reflection.
+func appendFields(zfields []zapcore.Field, fields []log.LogField) []zapcore.Field {
 	for _, field := range fields {
-		zfields = append(zfields, zap.Any(field.Key, field.Value))
+		zfields = append(zfields, convertField(field))
 	}
-
 	return zfields
 }
+
+func convertField(field log.LogField) zapcore.Field {
+	switch field.Kind {
+	case log.StringKind:
+		return zap.String(field.Key, field.Str)
+	case log.Int64Kind:
+		return zap.Int64(field.Key, field.Int)
+	case log.BoolKind:
+		return zap.Bool(field.Key, field.Int == 1)
+	case log.DurationKind:
+		return zap.Duration(field.Key, time.Duration(field.Int))
+	case log.TimeKind:
+		t := time.Unix(0, field.Int)
+		if loc, ok := field.Value.(*time.Location); ok {
+			t = t.In(loc)
+		}
+		return zap.Time(field.Key, t)
+	case log.ErrorKind:
+		err, _ := field.Value.(error)
+		return zap.NamedError(field.Key, err)
+	case log.StringerKind:
+		if v, ok := field.Value.(fmt.Stringer); ok {
+			return zap.Stringer(field.Key, v)
+		}
+		return zap.String(field.Key, "<nil>")
+	case log.ObjectKind:
+		return zap.Reflect(field.Key, field.Value)
+	default:
+		return zap.Any(field.Key, field.Value)
+	}
+}
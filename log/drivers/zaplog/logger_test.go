@@ -2,14 +2,23 @@ package zaplog_test
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hexastack-dev/devkit-go/log"
 	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
@@ -31,6 +40,41 @@ func TestLogger_Debug(t *testing.T) {
 	assert.Equal(t, zap.DebugLevel, observedLogs.All()[0].Level)
 }
 
+func TestLogger_Trace(t *testing.T) {
+	core, observedLogs := observer.New(zaplog.TraceLevel)
+	logger := zaplog.New(zap.New(core))
+	writeLog(logger, log.TraceLogLevel)
+
+	assert.Equal(t, 1, observedLogs.Len())
+	assert.Equal(t, "Hello", observedLogs.All()[0].Message)
+	assert.Equal(t, zaplog.TraceLevel, observedLogs.All()[0].Level)
+
+	var buf bytes.Buffer
+	dlogger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.DebugLogLevel,
+		Encoder:      zaplog.JSONEncoder,
+		Output:       &buf,
+	})
+	writeLog(dlogger, log.TraceLogLevel)
+	assert.Empty(t, buf.String())
+
+	dlogger.Level().SetLevel(log.TraceLogLevel)
+	writeLog(dlogger, log.TraceLogLevel)
+	assert.Contains(t, buf.String(), `"level":"trace"`)
+}
+
+func TestLogger_Panic(t *testing.T) {
+	core, observedLogs := observer.New(zap.InfoLevel)
+	logger := zaplog.New(zap.New(core))
+
+	assert.PanicsWithValue(t, "Something went wrong", func() {
+		logger.Panic("Something went wrong", errors.New("oopsie"))
+	})
+	require.Equal(t, 1, observedLogs.Len())
+	assert.Equal(t, zap.PanicLevel, observedLogs.All()[0].Level)
+	assert.Equal(t, "oopsie", observedLogs.All()[0].ContextMap()["error"])
+}
+
 func TestLogger_Info(t *testing.T) {
 	core, observedLogs := observer.New(zap.InfoLevel)
 	logger := zaplog.New(zap.New(core))
@@ -75,6 +119,274 @@ func TestLogger_WithFields(t *testing.T) {
 	assert.Equal(t, "value2", observedLogs.All()[0].ContextMap()["v2"])
 }
 
+func TestLogger_With(t *testing.T) {
+	core, observedLogs := observer.New(zap.InfoLevel)
+	logger := zaplog.New(zap.New(core)).With(log.Field("requestId", "abc"))
+	writeLog(logger.With(log.Field("tenant", "t1")), log.InfoLogLevel, log.Field("v1", "value1"))
+	writeLog(logger, log.InfoLogLevel)
+
+	assert.Equal(t, 2, observedLogs.Len())
+	assert.Equal(t, "abc", observedLogs.All()[0].ContextMap()["requestId"])
+	assert.Equal(t, "t1", observedLogs.All()[0].ContextMap()["tenant"])
+	assert.Equal(t, "value1", observedLogs.All()[0].ContextMap()["v1"])
+	assert.Equal(t, "abc", observedLogs.All()[1].ContextMap()["requestId"])
+	assert.NotContains(t, observedLogs.All()[1].ContextMap(), "tenant")
+}
+
+func TestLogger_Named(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.WarnLogLevel,
+		LoggerLevels: log.LevelOverrides{"payments.*": log.DebugLogLevel},
+		Encoder:      zaplog.JSONEncoder,
+		Output:       &buf,
+	})
+	writeLog(logger.Named("orders"), log.InfoLogLevel)
+	writeLog(logger.Named("orders"), log.WarnLogLevel)
+	writeLog(logger.Named("payments").Named("db"), log.DebugLogLevel)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], `"logger":"orders"`)
+	assert.Contains(t, lines[0], `"level":"warn"`)
+	assert.Contains(t, lines[1], `"logger":"payments.db"`)
+	assert.Contains(t, lines[1], `"level":"debug"`)
+}
+
+func TestLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       &buf,
+	})
+	child := logger.Named("payments")
+	writeLog(child, log.DebugLogLevel)
+	logger.Level().SetLevel(log.DebugLogLevel)
+	writeLog(child, log.DebugLogLevel)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 1, len(lines))
+	assert.Contains(t, lines[0], `"level":"debug"`)
+}
+
+func TestLogger_WithTypedFields(t *testing.T) {
+	core, observedLogs := observer.New(zap.InfoLevel)
+	logger := zaplog.New(zap.New(core))
+	ts := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+	writeLog(logger, log.InfoLogLevel,
+		log.String("s", "value"),
+		log.Int64("i", 42),
+		log.Bool("b", true),
+		log.Duration("d", time.Second),
+		log.Time("t", ts),
+		log.Err("e", errors.New("oopsie")),
+		log.Stringer("st", time.March),
+		log.Object("o", map[string]int{"a": 1}),
+	)
+
+	require.Equal(t, 1, observedLogs.Len())
+	ctxMap := observedLogs.All()[0].ContextMap()
+	assert.Equal(t, "value", ctxMap["s"])
+	assert.Equal(t, int64(42), ctxMap["i"])
+	assert.Equal(t, true, ctxMap["b"])
+	assert.Equal(t, time.Second, ctxMap["d"])
+	assert.True(t, ts.Equal(ctxMap["t"].(time.Time)))
+	assert.Equal(t, "oopsie", ctxMap["e"])
+	assert.Equal(t, "March", ctxMap["st"])
+	assert.Equal(t, map[string]int{"a": 1}, ctxMap["o"])
+}
+
+func TestLogger_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       &buf,
+		Sampling: &log.SamplerConfig{
+			Tick:       time.Minute,
+			Initial:    2,
+			Thereafter: 3,
+		},
+	})
+	for i := 0; i < 10; i++ {
+		writeErrorLog(logger, errors.New("oopsie"))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+}
+
+func TestLogger_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Output: io.Discard,
+		FileLogConfig: zaplog.FileLogConfig{
+			Enabled:  true,
+			Filename: path,
+		},
+	})
+	defer logger.Reopen()
+
+	logger.Info("first")
+	require.NoError(t, os.Rename(path, path+".1"))
+	logger.Info("second")
+	require.NoError(t, logger.Reopen())
+	logger.Info("third")
+
+	b, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"message":"second"`)
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), `"message":"second"`)
+	assert.Contains(t, string(b), `"message":"third"`)
+
+	require.NoError(t, logger.Rotate())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestLogger_AsyncOutput(t *testing.T) {
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, log.AsyncWriterConfig{})
+	defer aw.Close()
+
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       aw,
+	})
+	writeLog(logger, log.InfoLogLevel)
+	writeLog(logger, log.WarnLogLevel)
+	require.NoError(t, logger.Sync())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+}
+
+func TestLogger_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Encoder:      zaplog.JSONEncoder,
+		Output:       &buf,
+		Redaction: &log.RedactionPolicy{
+			Keys:   []string{"password", "*token*"},
+			Values: []*regexp.Regexp{regexp.MustCompile(`[\w.]+@[\w.]+`)},
+		},
+	})
+	logger.With(log.String("accessToken", "secret")).Error("Login failed", errors.New("unknown user john@example.com"),
+		log.String("password", "hunter2"),
+		log.String("email", "john@example.com"),
+		log.String("username", "john"),
+		log.Field("http", map[string]any{"header": map[string]string{"Authorization-Token": "Bearer x"}, "status": 401}),
+	)
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, map[string]any{"header": map[string]any{"Authorization-Token": "[REDACTED]"}, "status": float64(401)}, m["http"])
+	assert.Equal(t, "[REDACTED]", m["accessToken"])
+	assert.Equal(t, "[REDACTED]", m["password"])
+	assert.Equal(t, "[REDACTED]", m["email"])
+	assert.Equal(t, "john", m["username"])
+	assert.Equal(t, "unknown user [REDACTED]", m["error"])
+}
+
+func TestLogger_ErrorDetails(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Encoder:      zaplog.JSONEncoder,
+		Output:       &buf,
+		ErrorDetails: log.ErrorDetails{log.ErrorLogLevel: log.ErrorCauses},
+	})
+	err := fmt.Errorf("query failed: %w", errors.Join(errors.New("connection refused"), errors.New("timeout")))
+	logger.Named("db").Error("Something went wrong", err)
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "query failed: connection refused\ntimeout", m["error"])
+	assert.Equal(t, []any{"connection refused\ntimeout", "connection refused", "timeout"}, m["error.causes"])
+	assert.Nil(t, m["error.stack"])
+}
+
+func TestLogger_Hooks(t *testing.T) {
+	var global, local []log.HookEntry
+	log.SetHooks(func(e log.HookEntry) { global = append(global, e) })
+	t.Cleanup(func() {
+		log.SetHooks()
+	})
+
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       &noopWriter{},
+		Hooks:        []log.Hook{func(e log.HookEntry) { local = append(local, e) }},
+	})
+	err := errors.New("oopsie")
+	logger.Named("payments").With(log.String("requestId", "abc")).Error("Something went wrong", err, log.Int64("status", 500))
+	logger.Debug("Hello")
+
+	require.Equal(t, 1, len(global))
+	require.Equal(t, 1, len(local))
+	e := local[0]
+	assert.Equal(t, log.ErrorLogLevel, e.Level)
+	assert.Equal(t, "payments", e.Name)
+	assert.Equal(t, "Something went wrong", e.Message)
+	assert.Equal(t, err, e.Err)
+	assert.Equal(t, []log.LogField{log.String("requestId", "abc"), log.Int64("status", 500)}, e.Fields)
+
+	core, _ := observer.New(zap.InfoLevel)
+	zaplog.New(zap.New(core)).Warn("Careful")
+	require.Equal(t, 2, len(global))
+	assert.Equal(t, log.WarnLogLevel, global[1].Level)
+}
+
+func TestLogger_HooksContext(t *testing.T) {
+	var entries []log.HookEntry
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Encoder: zaplog.JSONEncoder,
+		Output:  &buf,
+		Hooks:   []log.Hook{func(e log.HookEntry) { entries = append(entries, e) }},
+	})
+	ctx := log.ContextWithRequestID(context.Background(), "abc")
+	logger.WithContext(ctx).Info("Hello")
+	logger.Info("Hello")
+
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, ctx, entries[0].Context)
+	assert.Equal(t, []log.LogField{log.String("requestId", "abc")}, entries[0].Fields)
+	assert.Nil(t, entries[1].Context)
+	assert.NotContains(t, buf.String(), "zaplog.context")
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	var code int
+	var flushed bool
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, log.AsyncWriterConfig{FlushInterval: time.Hour})
+	defer aw.Close()
+
+	log.SetExitFunc(func(c int) { code = c })
+	log.SetExitHandlers(func(ctx context.Context) error {
+		flushed = buf.Len() > 0
+		return nil
+	})
+	t.Cleanup(func() {
+		log.SetExitFunc(nil)
+		log.SetExitHandlers()
+	})
+
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       aw,
+	})
+	logger.Fatal("Something went wrong", errors.New("oopsie"))
+	assert.Equal(t, 1, code)
+	assert.True(t, flushed)
+	assert.Contains(t, buf.String(), "Something went wrong")
+}
+
 func TestLogger_WithContext(t *testing.T) {
 	ctx := context.Background()
 	tp := trace.NewTracerProvider()
@@ -94,6 +406,59 @@ func TestLogger_WithContext(t *testing.T) {
 	assert.NotEmpty(t, observedLogs.All()[0].ContextMap()["traceFlags"])
 }
 
+func TestLogger_WithContextTraceExtractor(t *testing.T) {
+	ctx, span := trace.NewTracerProvider().Tracer("").Start(context.Background(), "testTraceExtractor")
+	defer span.End()
+	// register extractor writing the same fields as otelctx.TraceContextExtractor.
+	log.SetContextExtractors(log.RequestIDExtractor, func(ctx context.Context) []log.LogField {
+		return []log.LogField{log.String("traceId", "dup"), log.String("spanId", "dup")}
+	})
+	t.Cleanup(func() {
+		log.SetContextExtractors(log.RequestIDExtractor)
+	})
+
+	core, observedLogs := observer.New(zap.InfoLevel)
+	logger := zaplog.New(zap.New(core))
+	logger.WithContext(log.ContextWithRequestID(ctx, "abc")).Info("With span")
+
+	require.Equal(t, 1, observedLogs.Len())
+	keys := make(map[string]int)
+	for _, f := range observedLogs.All()[0].Context {
+		keys[f.Key]++
+	}
+	assert.Equal(t, 1, keys["traceId"])
+	assert.Equal(t, 1, keys["spanId"])
+	assert.Equal(t, 1, keys["requestId"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), observedLogs.All()[0].ContextMap()["traceId"])
+}
+
+func TestLogger_DisabledLevel(t *testing.T) {
+	var extracted int
+	log.SetContextExtractors(func(ctx context.Context) []log.LogField {
+		extracted++
+		return nil
+	})
+	t.Cleanup(func() {
+		log.SetContextExtractors(log.RequestIDExtractor)
+	})
+
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.InfoLogLevel,
+		Output:       &buf,
+	}).WithContext(context.Background())
+	logger.Debug("Hello", log.String("a", "b"))
+	logger.Trace("Hello")
+	assert.Equal(t, 0, extracted)
+	assert.False(t, logger.(log.LevelEnabler).Enabled(log.DebugLogLevel))
+	assert.True(t, logger.(log.LevelEnabler).Enabled(log.InfoLogLevel))
+	assert.Empty(t, buf.String())
+
+	logger.Info("Hello")
+	logger.Error("Something went wrong", errors.New("oopsie"))
+	assert.Equal(t, 2, extracted)
+}
+
 func TestLogger_WriteToFile(t *testing.T) {
 	err := os.RemoveAll("./log")
 	if err != nil {
@@ -137,6 +502,8 @@ func TestLogger_WriteToFile(t *testing.T) {
 
 func writeLog(logger log.Logger, lv log.LogLevel, fields ...log.LogField) {
 	switch lv {
+	case log.TraceLogLevel:
+		logger.Trace("Hello", fields...)
 	case log.DebugLogLevel:
 		logger.Debug("Hello", fields...)
 	case log.InfoLogLevel:
@@ -170,6 +537,32 @@ func BenchmarkLogger(b *testing.B) {
 	b.Run("log error with 10 fields", func(b *testing.B) {
 		testError(b, logger)
 	})
+
+	b.ResetTimer()
+	b.Run("log with 10 typed fields", func(b *testing.B) {
+		testLogWithTypedArguments(b, logger)
+	})
+}
+
+func testLogWithTypedArguments(b *testing.B, logger log.Logger) {
+	for i := 0; i < b.N; i++ {
+		logger.Info("info message", generateTypedField(i)...)
+	}
+}
+
+func generateTypedField(i int) []log.LogField {
+	return []log.LogField{
+		log.String("a", "1"),
+		log.String("b", "2"),
+		log.String("c", "3"),
+		log.Int64("d", 4),
+		log.Int64("e", int64(i)),
+		log.Bool("f", true),
+		log.Duration("g", time.Second),
+		log.String("h", "s3"),
+		log.String("i", "s4"),
+		log.String("j", "s5"),
+	}
 }
 
 func testLog(b *testing.B, logger log.Logger) {
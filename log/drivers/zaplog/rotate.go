@@ -0,0 +1,200 @@
+package zaplog
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Rotation define time-based rotation interval of file log.
+type Rotation uint8
+
+const (
+	// NoRotation only rotates file log when it reaches FileLogConfig.MaxSize.
+	NoRotation Rotation = iota
+	// RotateHourly rotates file log at the start of every hour.
+	RotateHourly
+	// RotateDaily rotates file log at midnight.
+	RotateDaily
+)
+
+// String return lowercase name of the rotation, ie. "daily".
+func (r Rotation) String() string {
+	switch r {
+	case NoRotation:
+		return "none"
+	case RotateHourly:
+		return "hourly"
+	case RotateDaily:
+		return "daily"
+	default:
+		return fmt.Sprintf("Rotation(%d)", r)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, it return error for unknown rotation.
+func (r Rotation) MarshalText() ([]byte, error) {
+	if r > RotateDaily {
+		return nil, fmt.Errorf("unknown rotation: %d", r)
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler which allows Rotation to be read from
+// JSON or YAML configuration, accepted names are "none", "hourly" and "daily".
+func (r *Rotation) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "none", "":
+		*r = NoRotation
+	case "hourly":
+		*r = RotateHourly
+	case "daily":
+		*r = RotateDaily
+	default:
+		return fmt.Errorf("unrecognized rotation: %q", text)
+	}
+	return nil
+}
+
+// next return the start of the rotation interval after t.
+func (r Rotation) next(t time.Time) time.Time {
+	switch r {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+// rollingWriter wraps lumberjack.Logger to also rotate the file when the rotation interval
+// has passed. The check is done on write, thus no file is created for interval without logs.
+type rollingWriter struct {
+	lj        *lumberjack.Logger
+	rotation  Rotation
+	localTime bool
+	now       func() time.Time
+
+	mu sync.Mutex
+	// rotateAt is the time after which the next write rotates the file.
+	rotateAt time.Time
+
+	// sigc receives ReopenSignals, it's nil when there is no ReopenSignals.
+	sigc      chan os.Signal
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// newRollingWriter create rollingWriter for config which is reopened on config.ReopenSignals,
+// it panics if the log path cannot be resolved.
+func newRollingWriter(config FileLogConfig) *rollingWriter {
+	w := &rollingWriter{
+		lj:        rollingFile(config),
+		rotation:  config.Rotation,
+		localTime: config.LocalTime,
+		now:       time.Now,
+	}
+	if len(config.ReopenSignals) > 0 {
+		w.reopenOn(config.ReopenSignals)
+	}
+	return w
+}
+
+// rollingFile create lumberjack.Logger instance for config, it panics if the log path
+// cannot be resolved.
+func rollingFile(config FileLogConfig) *lumberjack.Logger {
+	path := config.Filename
+	if path == "" {
+		path = filepath.Base(os.Args[0]) + ".log"
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		panic(err)
+	}
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxBackups: config.MaxBackups,
+		MaxSize:    config.MaxSize,
+		MaxAge:     config.MaxAge,
+		Compress:   config.Compress,
+		LocalTime:  config.LocalTime,
+	}
+}
+
+func (w *rollingWriter) Write(p []byte) (int, error) {
+	if w.rotation != NoRotation {
+		w.mu.Lock()
+		now := w.in(w.now())
+		if w.rotateAt.IsZero() {
+			// existing file written in the previous interval is rotated on the first write.
+			t := now
+			if info, err := os.Stat(w.lj.Filename); err == nil {
+				t = w.in(info.ModTime())
+			}
+			w.rotateAt = w.rotation.next(t)
+		}
+		if !now.Before(w.rotateAt) {
+			w.rotateAt = w.rotation.next(now)
+			if err := w.lj.Rotate(); err != nil {
+				w.mu.Unlock()
+				return 0, err
+			}
+		}
+		w.mu.Unlock()
+	}
+	return w.lj.Write(p)
+}
+
+// Rotate closes current file, renames it as backup, then opens a new file.
+func (w *rollingWriter) Rotate() error {
+	return w.lj.Rotate()
+}
+
+// Reopen closes current file, the file is reopened on the next write and created if it
+// has been moved, ie. by logrotate.
+func (w *rollingWriter) Reopen() error {
+	return w.lj.Close()
+}
+
+// Close stops reopening the file on signals and closes the file, the file is reopened if
+// it's written after Close.
+func (w *rollingWriter) Close() error {
+	w.closeOnce.Do(func() {
+		if w.sigc != nil {
+			signal.Stop(w.sigc)
+			close(w.stop)
+		}
+	})
+	return w.lj.Close()
+}
+
+// reopenOn reopens the file whenever one of sigs is received until Close is called.
+func (w *rollingWriter) reopenOn(sigs []os.Signal) {
+	w.sigc = make(chan os.Signal, 1)
+	w.stop = make(chan struct{})
+	signal.Notify(w.sigc, sigs...)
+	go func() {
+		for {
+			select {
+			case <-w.sigc:
+				_ = w.Reopen()
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (w *rollingWriter) in(t time.Time) time.Time {
+	if w.localTime {
+		return t.Local()
+	}
+	return t.UTC()
+}
@@ -0,0 +1,125 @@
+package zaplog
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotation_Next(t *testing.T) {
+	loc := time.FixedZone("IST", 5*3600+1800)
+	now := time.Date(2023, 3, 31, 23, 45, 10, 0, loc)
+
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, loc), RotateHourly.next(now))
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, loc), RotateDaily.next(now))
+	assert.Equal(t, time.Date(2023, 3, 31, 11, 0, 0, 0, loc), RotateHourly.next(now.Add(-13*time.Hour)))
+	assert.True(t, NoRotation.next(now).IsZero())
+}
+
+func TestRollingWriter_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2023, 3, 31, 23, 59, 0, 0, time.UTC)
+	w := newRollingWriter(FileLogConfig{
+		Filename: filepath.Join(dir, "app.log"),
+		Rotation: RotateDaily,
+	})
+	w.now = func() time.Time { return now }
+	defer w.Reopen()
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Len(t, logFiles(t, dir), 1)
+
+	now = now.Add(time.Minute)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	files := logFiles(t, dir)
+	require.Len(t, files, 2)
+
+	b, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(b))
+}
+
+func TestRollingWriter_RotateExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("yesterday\n"), 0o644))
+	yesterday := time.Now().Add(-24 * time.Hour)
+	require.NoError(t, os.Chtimes(path, yesterday, yesterday))
+
+	w := newRollingWriter(FileLogConfig{Filename: path, Rotation: RotateDaily})
+	defer w.Reopen()
+
+	_, err := w.Write([]byte("today\n"))
+	require.NoError(t, err)
+	assert.Len(t, logFiles(t, dir), 2)
+}
+
+func logFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+	return files
+}
+
+func TestRollingWriter_Close(t *testing.T) {
+	// keeps SIGUSR1 from terminating the test after the writer stops listening to it.
+	testc := make(chan os.Signal, 1)
+	signal.Notify(testc, syscall.SIGUSR1)
+	defer signal.Stop(testc)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	w := newRollingWriter(FileLogConfig{Filename: path, ReopenSignals: []os.Signal{syscall.SIGUSR1}})
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+	select {
+	case <-w.stop:
+	default:
+		t.Fatal("reopen goroutine is not stopped")
+	}
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	select {
+	case <-testc:
+	case <-time.After(time.Second):
+		t.Fatal("signal is not received")
+	}
+	assert.Len(t, w.sigc, 0)
+}
+
+func TestLogger_Close(t *testing.T) {
+	logger := NewDefaultLogger(Config{
+		Output: io.Discard,
+		FileLogConfig: FileLogConfig{
+			Enabled:       true,
+			Filename:      filepath.Join(t.TempDir(), "app.log"),
+			ReopenSignals: []os.Signal{syscall.SIGUSR1},
+		},
+	})
+	logger.Info("Hello")
+
+	require.NoError(t, logger.Close())
+	require.Len(t, logger.files, 1)
+	select {
+	case <-logger.files[0].stop:
+	default:
+		t.Fatal("reopen goroutine is not stopped")
+	}
+}
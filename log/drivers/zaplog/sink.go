@@ -0,0 +1,306 @@
+package zaplog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SinkOutput define where Sink writes logs to.
+type SinkOutput string
+
+const (
+	// StdoutOutput writes logs to os.Stdout.
+	StdoutOutput SinkOutput = "stdout"
+	// StderrOutput writes logs to os.Stderr.
+	StderrOutput SinkOutput = "stderr"
+	// FileOutput writes logs to rolling file configured by Sink.File.
+	FileOutput SinkOutput = "file"
+	// SyslogOutput writes logs as syslog messages to local syslog socket.
+	SyslogOutput SinkOutput = "syslog"
+	// TCPOutput writes logs to TCP connection, one log per line.
+	TCPOutput SinkOutput = "tcp"
+	// UDPOutput writes logs to UDP connection, one log per datagram.
+	UDPOutput SinkOutput = "udp"
+)
+
+// Sink define an output where logs are written along with its encoder, minimum level and
+// field filter, ie. to write errors into separate file while writing all logs into stdout.
+type Sink struct {
+	// Output define where logs are written to. Default to StdoutOutput.
+	Output SinkOutput
+	// Writer overrides destination of Output, ie. to write syslog messages into custom writer.
+	Writer io.Writer
+	// Address is "host:port" for TCPOutput and UDPOutput, and path of the socket for SyslogOutput.
+	// SyslogOutput tries common socket paths, ie. /dev/log, when Address is empty.
+	// Connection is established on the first write and reestablished after write error.
+	Address string
+	// Tag is the syslog tag used by SyslogOutput. Default to the program name.
+	Tag string
+	// File configures rolling file for FileOutput, Enabled, LogLevel and Encoder are ignored.
+	File FileLogConfig
+	// Encoder to use to encode logs. Default to JSONEncoder.
+	Encoder Encoder
+	// LogLevel is the minimum level of logs written to the sink, logs must also pass
+	// Config.RootLogLevel and Config.LoggerLevels. Default to InfoLogLevel.
+	LogLevel log.LogLevel
+	// ExcludeFields define keys of fields which are not written to the sink, ie. to keep
+	// request payload out of a shared sink.
+	ExcludeFields []string
+}
+
+// validate return error when the sink is misconfigured, ie. unknown Output or TCPOutput
+// without Address.
+func (s Sink) validate() error {
+	if s.Writer != nil {
+		return nil
+	}
+	switch s.Output {
+	case StdoutOutput, "", StderrOutput, FileOutput, SyslogOutput:
+		return nil
+	case TCPOutput, UDPOutput:
+		if s.Address == "" {
+			return fmt.Errorf("zaplog: %s sink requires Address", s.Output)
+		}
+		return nil
+	default:
+		return fmt.Errorf("zaplog: unknown sink output %q", s.Output)
+	}
+}
+
+// build create zapcore.Core writing into the sink, file is not nil for FileOutput and conn is
+// not nil for SyslogOutput, TCPOutput and UDPOutput. It panics when the sink is misconfigured,
+// see validate.
+func (s Sink) build(spec encoderSpec, enabler zapcore.LevelEnabler) (core zapcore.Core, file *rollingWriter, conn *netWriter) {
+	if err := s.validate(); err != nil {
+		panic(err)
+	}
+	w := s.Writer
+	if w == nil {
+		switch s.Output {
+		case StdoutOutput, "":
+			w = os.Stdout
+		case StderrOutput:
+			w = os.Stderr
+		case FileOutput:
+			file = newRollingWriter(s.File)
+			w = file
+		case SyslogOutput:
+			conn = &netWriter{dial: dialSyslog(s.Address)}
+			w = conn
+		case TCPOutput, UDPOutput:
+			conn = &netWriter{dial: dialer(string(s.Output), s.Address)}
+			w = conn
+		}
+	}
+
+	enc := spec.newEncoder(s.Encoder)
+	if s.Output == SyslogOutput {
+		tag := s.Tag
+		if tag == "" {
+			tag = filepath.Base(os.Args[0])
+		}
+		core = &syslogCore{LevelEnabler: enabler, enc: enc, out: zapcore.AddSync(w), tag: tag}
+	} else {
+		core = zapcore.NewCore(enc, zapcore.AddSync(w), enabler)
+	}
+	if len(s.ExcludeFields) > 0 {
+		core = newFilterCore(core, s.ExcludeFields)
+	}
+	return core, file, conn
+}
+
+// sinkLevelEnabler enables zap level at or above min which also passes root level and overrides.
+func sinkLevelEnabler(root *log.AtomicLevel, overrides log.LevelOverrides, min log.LogLevel) zapcore.LevelEnabler {
+	rootEnabler := rootLevelEnabler(root, overrides)
+	minLvl := toZapLevel(min)
+	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= minLvl && rootEnabler.Enabled(lvl)
+	})
+}
+
+// filterCore drops fields which key is excluded before they are written by the wrapped core,
+// the wrapped core must add itself in Check only when it's enabled, ie. core created by zapcore.NewCore.
+type filterCore struct {
+	zapcore.Core
+	exclude map[string]struct{}
+}
+
+func newFilterCore(core zapcore.Core, keys []string) zapcore.Core {
+	exclude := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		exclude[k] = struct{}{}
+	}
+	return &filterCore{Core: core, exclude: exclude}
+}
+
+func (c *filterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &filterCore{Core: c.Core.With(c.filter(fields)), exclude: c.exclude}
+}
+
+func (c *filterCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *filterCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.filter(fields))
+}
+
+func (c *filterCore) filter(fields []zapcore.Field) []zapcore.Field {
+	for i, f := range fields {
+		if _, ok := c.exclude[f.Key]; !ok {
+			continue
+		}
+		filtered := make([]zapcore.Field, i, len(fields)-1)
+		copy(filtered, fields[:i])
+		for _, f := range fields[i+1:] {
+			if _, ok := c.exclude[f.Key]; !ok {
+				filtered = append(filtered, f)
+			}
+		}
+		return filtered
+	}
+	return fields
+}
+
+// syslogCore writes every entry as RFC 3164 message using user facility, the severity is
+// mapped from the entry level.
+type syslogCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	out zapcore.WriteSyncer
+	tag string
+}
+
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &syslogCore{LevelEnabler: c.LevelEnabler, enc: enc, out: c.out, tag: c.tag}
+}
+
+func (c *syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	b := make([]byte, 0, buf.Len()+64)
+	b = fmt.Appendf(b, "<%d>%s %s[%d]: ", syslogPriority(ent.Level), ent.Time.Format(time.Stamp), c.tag, os.Getpid())
+	b = append(b, bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})...)
+	_, err = c.out.Write(b)
+	return err
+}
+
+func (c *syslogCore) Sync() error {
+	return c.out.Sync()
+}
+
+// syslogPriority return priority of user facility with severity mapped from lvl.
+func syslogPriority(lvl zapcore.Level) int {
+	const facilityUser = 1 << 3
+	switch {
+	case lvl >= zapcore.FatalLevel:
+		return facilityUser | 1 // alert
+	case lvl >= zapcore.DPanicLevel:
+		return facilityUser | 2 // crit
+	case lvl >= zapcore.ErrorLevel:
+		return facilityUser | 3 // err
+	case lvl >= zapcore.WarnLevel:
+		return facilityUser | 4 // warning
+	case lvl >= zapcore.InfoLevel:
+		return facilityUser | 6 // info
+	default:
+		return facilityUser | 7 // debug
+	}
+}
+
+const dialTimeout = 5 * time.Second
+
+// netWriter writes into connection returned by dial, the connection is established on the
+// first write and reestablished on the next write after write error.
+type netWriter struct {
+	dial func() (net.Conn, error)
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (w *netWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		conn, err := w.dial()
+		if err != nil {
+			return 0, err
+		}
+		w.conn = conn
+	}
+	n, err := w.conn.Write(p)
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	return n, err
+}
+
+// Close closes current connection, a new connection is established on the next write.
+func (w *netWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+func dialer(network, address string) func() (net.Conn, error) {
+	return func() (net.Conn, error) {
+		return net.DialTimeout(network, address, dialTimeout)
+	}
+}
+
+// dialSyslog dials local syslog socket at address, or common socket paths when address is empty.
+func dialSyslog(address string) func() (net.Conn, error) {
+	paths := []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+	if address != "" {
+		paths = []string{address}
+	}
+	return func() (net.Conn, error) {
+		var err error
+		for _, path := range paths {
+			for _, network := range []string{"unixgram", "unix"} {
+				var conn net.Conn
+				if conn, err = net.DialTimeout(network, path, dialTimeout); err == nil {
+					return conn, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("unable to connect to local syslog: %w", err)
+	}
+}
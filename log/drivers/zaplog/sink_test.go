@@ -0,0 +1,130 @@
+package zaplog_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/drivers/zaplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_Sinks(t *testing.T) {
+	var all, errs bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		RootLogLevel: log.DebugLogLevel,
+		LoggerLevels: log.LevelOverrides{"payments": log.WarnLogLevel},
+		Sinks: []zaplog.Sink{
+			{Writer: &all, Encoder: zaplog.ConsoleEncoder, LogLevel: log.DebugLogLevel, ExcludeFields: []string{"payload"}},
+			{Writer: &errs, LogLevel: log.ErrorLogLevel},
+		},
+	})
+
+	logger.With(log.String("payload", "secret")).Debug("Hello", log.String("payload", "secret"), log.Int64("status", 200))
+	logger.Named("payments").Info("Hello")
+	writeErrorLog(logger, errors.New("oopsie"), log.String("payload", "secret"))
+
+	lines := strings.Split(strings.TrimSpace(all.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], "debug")
+	assert.Contains(t, lines[0], `{"status": 200}`)
+	assert.Contains(t, lines[1], "Something went wrong")
+	assert.NotContains(t, all.String(), "secret")
+
+	lines = strings.Split(strings.TrimSpace(errs.String()), "\n")
+	require.Equal(t, 1, len(lines))
+	assert.Contains(t, lines[0], `"level":"error"`)
+	assert.Contains(t, lines[0], `"error":"oopsie"`)
+	assert.Contains(t, lines[0], `"payload":"secret"`)
+}
+
+func TestLogger_SyslogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Sinks: []zaplog.Sink{{Output: zaplog.SyslogOutput, Writer: &buf, Tag: "app"}},
+	})
+	writeErrorLog(logger.With(log.String("requestId", "abc")), errors.New("oopsie"))
+
+	msg := buf.String()
+	assert.True(t, strings.HasPrefix(msg, "<11>"), msg)
+	assert.Contains(t, msg, " app[")
+	assert.True(t, strings.HasSuffix(msg, `"requestId":"abc","error":"oopsie"}`), msg)
+}
+
+func TestLogger_TCPSink(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Sinks: []zaplog.Sink{{Output: zaplog.TCPOutput, Address: ln.Addr().String()}},
+	})
+	logger.Info("Hello")
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, `"message":"Hello"`)
+
+	require.NoError(t, logger.Close())
+	_, err = r.ReadString('\n')
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestLogger_FileSink(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaplog.NewDefaultLogger(zaplog.Config{
+		Sinks: []zaplog.Sink{
+			{Output: zaplog.FileOutput, File: zaplog.FileLogConfig{Filename: filepath.Join(dir, "app.log")}},
+			{Output: zaplog.FileOutput, File: zaplog.FileLogConfig{Filename: filepath.Join(dir, "error.log")}, LogLevel: log.ErrorLogLevel},
+		},
+	})
+	defer logger.Reopen()
+
+	logger.Info("Hello")
+	writeErrorLog(logger, errors.New("oopsie"))
+
+	b, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "\n"))
+	b, err = os.ReadFile(filepath.Join(dir, "error.log"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(b), "\n"))
+
+	require.NoError(t, logger.Rotate())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
+
+func TestLogger_InvalidSink(t *testing.T) {
+	assert.PanicsWithError(t, `zaplog: unknown sink output "kafka"`, func() {
+		zaplog.NewDefaultLogger(zaplog.Config{Sinks: []zaplog.Sink{{Output: "kafka"}}})
+	})
+	assert.PanicsWithError(t, "zaplog: udp sink requires Address", func() {
+		zaplog.NewDefaultLogger(zaplog.Config{Sinks: []zaplog.Sink{{Output: zaplog.UDPOutput}}})
+	})
+
+	logger, err := zaplog.NewLogger(zaplog.Config{Sinks: []zaplog.Sink{
+		{Output: zaplog.StdoutOutput},
+		{Output: "kafka"},
+		{Output: zaplog.TCPOutput},
+	}})
+	assert.Nil(t, logger)
+	assert.EqualError(t, err, "zaplog: unknown sink output \"kafka\"\nzaplog: tcp sink requires Address")
+
+	logger, err = zaplog.NewLogger(zaplog.Config{Sinks: []zaplog.Sink{{Output: zaplog.StderrOutput}}})
+	require.NoError(t, err)
+	assert.NotNil(t, logger)
+}
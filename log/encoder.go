@@ -0,0 +1,280 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Encoder define how SimpleLogger encodes log entry.
+type Encoder uint8
+
+const (
+	// TextEncoder encodes entry as tab separated key:value pairs, values are written as is.
+	TextEncoder Encoder = iota
+	// JSONEncoder encodes entry as JSON object, one object per line.
+	JSONEncoder
+	// LogfmtEncoder encodes entry as space separated key=value pairs, values are quoted when needed.
+	LogfmtEncoder
+)
+
+const timeFormat = "2006-01-02T15:04:05.000Z0700"
+
+// entry is a log entry to be encoded.
+type entry struct {
+	time      time.Time
+	level     LogLevel
+	name      string
+	msg       string
+	err       error
+	errfields []LogField
+	fields    []LogField
+	optfields []LogField
+	ctxfields []LogField
+}
+
+func (enc Encoder) appendEntry(b []byte, e *entry) []byte {
+	switch enc {
+	case JSONEncoder:
+		return appendJSONEntry(b, e)
+	case LogfmtEncoder:
+		return appendLogfmtEntry(b, e)
+	default:
+		return appendTextEntry(b, e)
+	}
+}
+
+func appendTextEntry(b []byte, e *entry) []byte {
+	b = append(b, "timestamp:"...)
+	b = e.time.AppendFormat(b, timeFormat)
+	b = append(b, "\tlevel:"...)
+	b = append(b, e.level.String()...)
+
+	if e.name != "" {
+		b = append(b, "\tlogger:"...)
+		b = append(b, e.name...)
+	}
+
+	b = append(b, "\tmessage:"...)
+	b = append(b, e.msg...)
+
+	if e.err != nil {
+		b = append(b, "\terror:"...)
+		b = append(b, e.err.Error()...)
+	}
+
+	b = appendTextFields(b, e.errfields)
+	b = appendTextFields(b, e.fields)
+	b = appendTextFields(b, e.optfields)
+	b = appendTextFields(b, e.ctxfields)
+	return b
+}
+
+func appendTextFields(b []byte, fields []LogField) []byte {
+	for _, field := range fields {
+		b = append(b, '\t')
+		b = append(b, field.Key...)
+		b = append(b, ':')
+		b = appendFieldValue(b, field)
+	}
+	return b
+}
+
+// appendFieldValue append field value as plain text.
+func appendFieldValue(b []byte, field LogField) []byte {
+	switch field.Kind {
+	case StringKind:
+		return append(b, field.Str...)
+	case Int64Kind:
+		return strconv.AppendInt(b, field.Int, 10)
+	case BoolKind:
+		return strconv.AppendBool(b, field.Int == 1)
+	case DurationKind:
+		return append(b, time.Duration(field.Int).String()...)
+	case TimeKind:
+		return field.time().AppendFormat(b, time.RFC3339Nano)
+	case ErrorKind:
+		if err, ok := field.Value.(error); ok && err != nil {
+			return append(b, err.Error()...)
+		}
+		return append(b, "<nil>"...)
+	case StringerKind:
+		// fmt writes "<nil>" for nil and typed nil pointer which String panics.
+		return fmt.Append(b, field.Value)
+	default:
+		return fmt.Append(b, field.Value)
+	}
+}
+
+func appendJSONEntry(b []byte, e *entry) []byte {
+	b = append(b, `{"timestamp":"`...)
+	b = e.time.AppendFormat(b, timeFormat)
+	b = append(b, `","level":"`...)
+	b = append(b, e.level.String()...)
+	b = append(b, '"')
+
+	if e.name != "" {
+		b = append(b, `,"logger":`...)
+		b = appendJSONString(b, e.name)
+	}
+
+	b = append(b, `,"message":`...)
+	b = appendJSONString(b, e.msg)
+
+	if e.err != nil {
+		b = append(b, `,"error":`...)
+		b = appendJSONString(b, e.err.Error())
+	}
+
+	b = appendJSONFields(b, e.errfields)
+	b = appendJSONFields(b, e.fields)
+	b = appendJSONFields(b, e.optfields)
+	b = appendJSONFields(b, e.ctxfields)
+	return append(b, '}')
+}
+
+func appendJSONFields(b []byte, fields []LogField) []byte {
+	for _, field := range fields {
+		b = append(b, ',')
+		b = appendJSONString(b, field.Key)
+		b = append(b, ':')
+		b = appendJSONValue(b, field)
+	}
+	return b
+}
+
+func appendJSONValue(b []byte, field LogField) []byte {
+	switch field.Kind {
+	case Int64Kind:
+		return strconv.AppendInt(b, field.Int, 10)
+	case BoolKind:
+		return strconv.AppendBool(b, field.Int == 1)
+	case StringKind:
+		return appendJSONString(b, field.Str)
+	case AnyKind, ObjectKind:
+		if err, ok := field.Value.(error); ok {
+			return appendJSONString(b, err.Error())
+		}
+		if v, err := json.Marshal(field.Value); err == nil {
+			return append(b, v...)
+		}
+		return appendJSONString(b, fmt.Sprint(field.Value))
+	default:
+		return appendJSONString(b, string(appendFieldValue(nil, field)))
+	}
+}
+
+// appendJSONString append s as quoted JSON string, invalid UTF-8 is replaced with U+FFFD.
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < 0x20:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, "\ufffd"...)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+func appendLogfmtEntry(b []byte, e *entry) []byte {
+	b = append(b, "timestamp="...)
+	b = e.time.AppendFormat(b, timeFormat)
+	b = append(b, " level="...)
+	b = append(b, e.level.String()...)
+
+	if e.name != "" {
+		b = append(b, " logger="...)
+		b = appendLogfmtValue(b, e.name)
+	}
+
+	b = append(b, " message="...)
+	b = appendLogfmtValue(b, e.msg)
+
+	if e.err != nil {
+		b = append(b, " error="...)
+		b = appendLogfmtValue(b, e.err.Error())
+	}
+
+	b = appendLogfmtFields(b, e.errfields)
+	b = appendLogfmtFields(b, e.fields)
+	b = appendLogfmtFields(b, e.optfields)
+	b = appendLogfmtFields(b, e.ctxfields)
+	return b
+}
+
+func appendLogfmtFields(b []byte, fields []LogField) []byte {
+	for _, field := range fields {
+		b = append(b, ' ')
+		b = appendLogfmtKey(b, field.Key)
+		b = append(b, '=')
+		switch field.Kind {
+		case Int64Kind:
+			b = strconv.AppendInt(b, field.Int, 10)
+		case BoolKind:
+			b = strconv.AppendBool(b, field.Int == 1)
+		case StringKind:
+			b = appendLogfmtValue(b, field.Str)
+		default:
+			b = appendLogfmtValue(b, string(appendFieldValue(nil, field)))
+		}
+	}
+	return b
+}
+
+// appendLogfmtKey append k replacing characters which are not allowed in logfmt key with '_'.
+func appendLogfmtKey(b []byte, k string) []byte {
+	if k == "" {
+		return append(b, '_')
+	}
+	for _, r := range k {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			b = append(b, '_')
+		} else {
+			b = utf8.AppendRune(b, r)
+		}
+	}
+	return b
+}
+
+// appendLogfmtValue append s as logfmt value, s is quoted when it's empty or
+// contains space, '=', '"' or control characters.
+func appendLogfmtValue(b []byte, s string) []byte {
+	if s != "" && !needsLogfmtQuote(s) {
+		return append(b, s...)
+	}
+	return strconv.AppendQuote(b, s)
+}
+
+func needsLogfmtQuote(s string) bool {
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
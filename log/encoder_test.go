@@ -0,0 +1,60 @@
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimpleLogger_JSONEncoder(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel, log.WithEncoder(log.JSONEncoder))
+
+	logger.Named("payments").With(log.String("requestId", "abc")).Error("Something \"went\"\nwrong", errors.New("oopsie"),
+		log.Int64("status", 500),
+		log.Bool("retry", false),
+		log.Duration("elapsed", time.Second),
+		log.Object("http", map[string]string{"method": "GET"}),
+		log.Field("invalid", "\x00\xff"),
+	)
+	require.Equal(t, 1, len(observer.entries))
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(observer.entries[0]), &m))
+	assert.NotEmpty(t, m["timestamp"])
+	assert.Equal(t, "error", m["level"])
+	assert.Equal(t, "payments", m["logger"])
+	assert.Equal(t, "Something \"went\"\nwrong", m["message"])
+	assert.Equal(t, "oopsie", m["error"])
+	assert.Equal(t, "abc", m["requestId"])
+	assert.Equal(t, float64(500), m["status"])
+	assert.Equal(t, false, m["retry"])
+	assert.Equal(t, "1s", m["elapsed"])
+	assert.Equal(t, map[string]any{"method": "GET"}, m["http"])
+	assert.Equal(t, "\x00�", m["invalid"])
+}
+
+func TestSimpleLogger_LogfmtEncoder(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel, log.WithEncoder(log.LogfmtEncoder))
+
+	logger.Named("payments").Error("Something went wrong", errors.New("oopsie"),
+		log.String("requestId", "abc"),
+		log.String("empty", ""),
+		log.String("quoted", `say "hi"`),
+		log.Int64("status", 500),
+		log.Field("invalid key", "a=b"),
+	)
+	require.Equal(t, 1, len(observer.entries))
+	line := observer.entries[0]
+	require.True(t, strings.HasPrefix(line, "timestamp="))
+	i := strings.Index(line, " level=")
+	require.Greater(t, i, 0)
+	assert.Equal(t, ` level=error logger=payments message="Something went wrong" error=oopsie requestId=abc empty="" quoted="say \"hi\"" status=500 invalid_key="a=b"`+"\n", line[i:])
+}
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// StackTracer is implemented by errors which carry stack trace captured when they are
+// created, such as errors created using WithStack option of devkit-go errors package.
+// StackTrace return program counters which are resolved using runtime.CallersFrames.
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// ErrorDetail is a set of details rendered for logged error.
+type ErrorDetail uint8
+
+const (
+	// ErrorStack renders "error.stack" field containing frames of stack trace carried by
+	// the error, see StackTracer.
+	ErrorStack ErrorDetail = 1 << iota
+	// ErrorCauses renders "error.causes" field containing message of every error wrapped
+	// by the error, either using %w or errors.Join.
+	ErrorCauses
+)
+
+// ErrorDetails define which details are rendered for error passed to Error or Fatal per
+// level, ie. {log.FatalLogLevel: log.ErrorStack | log.ErrorCauses, log.ErrorLogLevel: log.ErrorCauses}.
+// No details are rendered for levels which are not in the map.
+type ErrorDetails map[LogLevel]ErrorDetail
+
+// AppendFields append fields describing details of err enabled for lv into fields.
+func (d ErrorDetails) AppendFields(fields []LogField, lv LogLevel, err error) []LogField {
+	if err == nil {
+		return fields
+	}
+	detail := d[lv]
+	if detail&ErrorStack != 0 {
+		var st StackTracer
+		if errors.As(err, &st) {
+			fields = append(fields, Field("error.stack", stackFrames(st.StackTrace())))
+		}
+	}
+	if detail&ErrorCauses != 0 {
+		if causes := appendCauses(nil, err); len(causes) > 0 {
+			fields = append(fields, Field("error.causes", causes))
+		}
+	}
+	return fields
+}
+
+// stackFrames format program counters as "function file:line".
+func stackFrames(pcs []uintptr) []string {
+	frames := runtime.CallersFrames(pcs)
+	s := make([]string, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" || frame.File != "" {
+			s = append(s, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			return s
+		}
+	}
+}
+
+// appendCauses append message of every error wrapped by err in depth-first order, wrapper
+// which doesn't change the message, ie. one which only carries stack trace, is skipped.
+func appendCauses(causes []string, err error) []string {
+	var errs []error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		errs = []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		errs = u.Unwrap()
+	}
+	msg := err.Error()
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if m := e.Error(); m != msg {
+			causes = append(causes, m)
+		}
+		causes = appendCauses(causes, e)
+	}
+	return causes
+}
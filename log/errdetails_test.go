@@ -0,0 +1,75 @@
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stackError struct {
+	error
+	stack []uintptr
+}
+
+func (e *stackError) Unwrap() error {
+	return e.error
+}
+
+func (e *stackError) StackTrace() []uintptr {
+	return e.stack
+}
+
+func newStackError(msg string) error {
+	pcs := make([]uintptr, 8)
+	n := runtime.Callers(2, pcs)
+	return &stackError{error: errors.New(msg), stack: pcs[:n]}
+}
+
+func TestErrorDetails_AppendFields(t *testing.T) {
+	details := log.ErrorDetails{
+		log.FatalLogLevel: log.ErrorStack | log.ErrorCauses,
+		log.ErrorLogLevel: log.ErrorCauses,
+	}
+	root := newStackError("connection refused")
+	err := fmt.Errorf("query failed: %w", errors.Join(root, errors.New("timeout")))
+
+	fields := details.AppendFields(nil, log.FatalLogLevel, err)
+	require.Equal(t, 2, len(fields))
+	assert.Equal(t, "error.stack", fields[0].Key)
+	stack := fields[0].Any().([]string)
+	require.NotEmpty(t, stack)
+	assert.True(t, strings.HasPrefix(stack[0], "github.com/hexastack-dev/devkit-go/log_test.TestErrorDetails_AppendFields "), stack[0])
+	assert.Equal(t, log.Field("error.causes", []string{"connection refused\ntimeout", "connection refused", "timeout"}), fields[1])
+
+	fields = details.AppendFields(nil, log.ErrorLogLevel, err)
+	require.Equal(t, 1, len(fields))
+	assert.Equal(t, "error.causes", fields[0].Key)
+
+	assert.Empty(t, details.AppendFields(nil, log.ErrorLogLevel, errors.New("oopsie")))
+	assert.Empty(t, details.AppendFields(nil, log.WarnLogLevel, err))
+	assert.Empty(t, details.AppendFields(nil, log.FatalLogLevel, nil))
+}
+
+func TestSimpleLogger_ErrorDetails(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel,
+		log.WithEncoder(log.JSONEncoder),
+		log.WithErrorDetails(log.ErrorDetails{log.ErrorLogLevel: log.ErrorStack | log.ErrorCauses}))
+
+	logger.Error("Something went wrong", fmt.Errorf("dang: %w", newStackError("oopsie")), log.String("requestId", "abc"))
+	require.Equal(t, 1, len(observer.entries))
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(observer.entries[0]), &m))
+	assert.Equal(t, "dang: oopsie", m["error"])
+	assert.Equal(t, []any{"oopsie"}, m["error.causes"])
+	assert.NotEmpty(t, m["error.stack"])
+	assert.Equal(t, "abc", m["requestId"])
+}
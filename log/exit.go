@@ -0,0 +1,135 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ExitHandler is called before the program exits due to Fatal log, ie. to flush buffered
+// logs or to run shutdown listeners. ctx is done when exit timeout is reached, see SetExitTimeout.
+type ExitHandler func(ctx context.Context) error
+
+var (
+	exitMu       sync.Mutex
+	exitFunc     = os.Exit
+	exitTimeout  = 5 * time.Second
+	exitHandlers []ExitHandler
+	// exitDone is closed when handlers run by Exit complete, it's nil when Exit is not running.
+	exitDone chan struct{}
+	// exitRunner is id of goroutine running the handlers.
+	exitRunner uint64
+)
+
+// SetExitFunc replace function called by Exit to exit the program, ie. to assert Fatal
+// in tests without exiting the test binary. Passing nil restores the default os.Exit.
+func SetExitFunc(fn func(code int)) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	if fn == nil {
+		fn = os.Exit
+	}
+	exitFunc = fn
+}
+
+// SetExitTimeout set maximum duration to wait for exit handlers to complete, default to 5 seconds.
+func SetExitTimeout(timeout time.Duration) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	exitTimeout = timeout
+}
+
+// AddExitHandler register handlers to be called by Exit. Handlers are called one by one in
+// the reverse order they are registered, similar to defer, so handler registered early,
+// such as the one which flushes logger, is called after the rest.
+func AddExitHandler(handlers ...ExitHandler) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	exitHandlers = append(exitHandlers[:len(exitHandlers):len(exitHandlers)], handlers...)
+}
+
+// SetExitHandlers replace all registered exit handlers, calling it without argument
+// will remove all exit handlers.
+func SetExitHandlers(handlers ...ExitHandler) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	exitHandlers = append([]ExitHandler(nil), handlers...)
+}
+
+// Exit calls registered exit handlers and wait until they complete or exit timeout is
+// reached, then exit the program using exit function, see SetExitFunc. Errors returned
+// by the handlers are ignored since the program is exiting anyway. All logger drivers
+// call Exit(1) after writing Fatal log.
+//
+// When Exit is called by another goroutine while the handlers are running, it waits until
+// they complete before exiting. When it's called from inside a handler, ie. the handler
+// calls Fatal, the program exits immediately.
+func Exit(code int) {
+	exitMu.Lock()
+	exit, timeout, handlers := exitFunc, exitTimeout, exitHandlers
+	if done := exitDone; done != nil {
+		reentered := exitRunner == goroutineID()
+		exitMu.Unlock()
+		if !reentered {
+			<-done
+		}
+		exit(code)
+		return
+	}
+	done := make(chan struct{})
+	exitDone = done
+	exitMu.Unlock()
+
+	runExitHandlers(timeout, handlers)
+	exitMu.Lock()
+	exitDone = nil
+	exitMu.Unlock()
+	close(done)
+	exit(code)
+}
+
+func runExitHandlers(timeout time.Duration, handlers []ExitHandler) {
+	if len(handlers) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		exitMu.Lock()
+		exitRunner = goroutineID()
+		exitMu.Unlock()
+		for i := len(handlers) - 1; i >= 0; i-- {
+			if ctx.Err() != nil {
+				return
+			}
+			_ = handlers[i](ctx)
+		}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// goroutineID return id of the current goroutine parsed from its stack trace header,
+// ie. "goroutine 18 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,124 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setExitFunc(t *testing.T) *[]int {
+	codes := &[]int{}
+	log.SetExitFunc(func(code int) {
+		*codes = append(*codes, code)
+	})
+	t.Cleanup(func() {
+		log.SetExitFunc(nil)
+		log.SetExitHandlers()
+		log.SetExitTimeout(5 * time.Second)
+	})
+	return codes
+}
+
+func TestExit(t *testing.T) {
+	codes := setExitFunc(t)
+	var calls []string
+	log.AddExitHandler(func(ctx context.Context) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	log.AddExitHandler(func(ctx context.Context) error {
+		calls = append(calls, "second")
+		return errors.New("oopsie")
+	})
+
+	log.Exit(2)
+	assert.Equal(t, []int{2}, *codes)
+	assert.Equal(t, []string{"second", "first"}, calls)
+}
+
+func TestExit_Timeout(t *testing.T) {
+	codes := setExitFunc(t)
+	log.SetExitTimeout(10 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	log.SetExitHandlers(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	log.Exit(1)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []int{1}, *codes)
+}
+
+func TestExit_Reentrant(t *testing.T) {
+	codes := setExitFunc(t)
+	log.SetExitHandlers(func(ctx context.Context) error {
+		log.Exit(3)
+		return nil
+	})
+
+	log.Exit(1)
+	assert.Equal(t, []int{3, 1}, *codes)
+}
+
+func TestFatal_Exit(t *testing.T) {
+	codes := setExitFunc(t)
+	observer := &logObserver{}
+	var flushed bool
+	log.SetExitHandlers(func(ctx context.Context) error {
+		flushed = len(observer.entries) == 1
+		return nil
+	})
+
+	log.NewSimpleLogger(observer, log.InfoLogLevel).Fatal("Something went wrong", errors.New("oopsie"))
+	require.Equal(t, 1, len(observer.entries))
+	assert.Equal(t, "level:fatal\tmessage:Something went wrong\terror:oopsie\n", observer.entries[0][39:])
+	assert.True(t, flushed)
+
+	(&log.NoOpLogger{}).Fatal("Something went wrong", nil)
+	assert.Equal(t, []int{1, 1}, *codes)
+}
+
+func TestExit_Concurrent(t *testing.T) {
+	var mu sync.Mutex
+	var codes []int
+	exited := make(chan struct{}, 2)
+	log.SetExitFunc(func(code int) {
+		mu.Lock()
+		codes = append(codes, code)
+		mu.Unlock()
+		exited <- struct{}{}
+	})
+	t.Cleanup(func() {
+		log.SetExitFunc(nil)
+		log.SetExitHandlers()
+	})
+	started, release := make(chan struct{}), make(chan struct{})
+	log.SetExitHandlers(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	go log.Exit(1)
+	<-started
+	go log.NewSimpleLogger(&logObserver{}, log.InfoLogLevel).Fatal("Something went wrong", nil)
+	select {
+	case <-exited:
+		t.Fatal("concurrent Fatal exited while handler is running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-exited
+	<-exited
+	assert.Equal(t, []int{1, 1}, codes)
+}
@@ -1,14 +1,132 @@
 package log
 
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FieldKind tells how the value of LogField is stored, this allows logger driver
+// to encode the value without reflection.
+type FieldKind uint8
+
+const (
+	// AnyKind field stores its value in Value, this is the kind of field created by Field.
+	AnyKind FieldKind = iota
+	// StringKind field stores its value in Str.
+	StringKind
+	// Int64Kind field stores its value in Int.
+	Int64Kind
+	// BoolKind field stores its value in Int, 1 for true and 0 for false.
+	BoolKind
+	// DurationKind field stores its value in Int as nanoseconds.
+	DurationKind
+	// TimeKind field stores its value in Int as unix nanoseconds, and its location in Value.
+	TimeKind
+	// ErrorKind field stores its value in Value as error.
+	ErrorKind
+	// StringerKind field stores its value in Value as fmt.Stringer, String is called lazily.
+	StringerKind
+	// ObjectKind field stores its value in Value, and should be encoded as structured object.
+	ObjectKind
+)
+
 // LogField is an additional structured field
 type LogField struct {
 	Key   string
 	Value any
+	Kind  FieldKind
+	Int   int64
+	Str   string
 }
 
+// Field create LogField of AnyKind, logger driver might use reflection to encode v.
+// Prefer typed constructors such as String or Int64 when the type is known.
 func Field(k string, v any) LogField {
 	return LogField{
 		Key:   k,
 		Value: v,
 	}
 }
+
+// String create LogField with string value.
+func String(k string, v string) LogField {
+	return LogField{Key: k, Kind: StringKind, Str: v}
+}
+
+// Int64 create LogField with int64 value.
+func Int64(k string, v int64) LogField {
+	return LogField{Key: k, Kind: Int64Kind, Int: v}
+}
+
+// Bool create LogField with bool value.
+func Bool(k string, v bool) LogField {
+	var i int64
+	if v {
+		i = 1
+	}
+	return LogField{Key: k, Kind: BoolKind, Int: i}
+}
+
+// Duration create LogField with time.Duration value.
+func Duration(k string, v time.Duration) LogField {
+	return LogField{Key: k, Kind: DurationKind, Int: int64(v)}
+}
+
+// Time create LogField with time.Time value. Time which cannot be represented as
+// unix nanoseconds, such as zero time, is stored as AnyKind.
+func Time(k string, v time.Time) LogField {
+	if v.Before(minTime) || v.After(maxTime) {
+		return Field(k, v)
+	}
+	return LogField{Key: k, Kind: TimeKind, Int: v.UnixNano(), Value: v.Location()}
+}
+
+// Err create LogField with error value.
+func Err(k string, err error) LogField {
+	return LogField{Key: k, Kind: ErrorKind, Value: err}
+}
+
+// Stringer create LogField with fmt.Stringer value, String will only be called
+// when the field is written.
+func Stringer(k string, v fmt.Stringer) LogField {
+	return LogField{Key: k, Kind: StringerKind, Value: v}
+}
+
+// Object create LogField which value should be encoded as structured object,
+// ie. JSON object when the logger driver use JSON encoding.
+func Object(k string, v any) LogField {
+	return LogField{Key: k, Kind: ObjectKind, Value: v}
+}
+
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Any return the field value regardless of its kind, this method might allocates,
+// thus logger driver should prefer to switch on Kind and read the value directly.
+func (f LogField) Any() any {
+	switch f.Kind {
+	case StringKind:
+		return f.Str
+	case Int64Kind:
+		return f.Int
+	case BoolKind:
+		return f.Int == 1
+	case DurationKind:
+		return time.Duration(f.Int)
+	case TimeKind:
+		return f.time()
+	default:
+		return f.Value
+	}
+}
+
+func (f LogField) time() time.Time {
+	t := time.Unix(0, f.Int)
+	if loc, ok := f.Value.(*time.Location); ok {
+		return t.In(loc)
+	}
+	return t
+}
@@ -0,0 +1,25 @@
+package log_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogField_Any(t *testing.T) {
+	ts := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "value", log.String("k", "value").Any())
+	assert.Equal(t, int64(42), log.Int64("k", 42).Any())
+	assert.Equal(t, true, log.Bool("k", true).Any())
+	assert.Equal(t, false, log.Bool("k", false).Any())
+	assert.Equal(t, time.Second, log.Duration("k", time.Second).Any())
+	assert.Equal(t, ts, log.Time("k", ts).Any())
+	assert.Equal(t, time.March, log.Stringer("k", time.March).Any())
+	assert.Equal(t, 1, log.Field("k", 1).Any())
+
+	zero := log.Time("k", time.Time{})
+	assert.Equal(t, log.AnyKind, zero.Kind)
+	assert.Equal(t, time.Time{}, zero.Any())
+}
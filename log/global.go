@@ -3,27 +3,41 @@ package log
 import (
 	"context"
 	"log"
+	"sync/atomic"
 )
 
-var globalLogger Logger
+// loggerHolder wraps Logger so it can be stored in atomic.Pointer.
+type loggerHolder struct {
+	logger Logger
+}
+
+var globalLogger atomic.Pointer[loggerHolder]
 
 func init() {
-	globalLogger = NewSimpleLogger(log.Default().Writer(), DebugLogLevel)
+	globalLogger.Store(&loggerHolder{NewSimpleLogger(log.Default().Writer(), DebugLogLevel)})
 }
 
 // GetLogger get global logger, by default global logger use SimpleLogger and use
 // standard log default writer (log.Default().Writer()) as it's writer.
 // Use SetLogger to set global logger.
+//
+// The returned Logger doesn't follow subsequent SetLogger calls, use Delegating
+// to get Logger which always writes into the current global logger.
 func GetLogger() Logger {
-	return globalLogger
+	return globalLogger.Load().logger
 }
 
-// SetLogger set global logger.
+// SetLogger set global logger, it's safe to be called while other goroutines are logging.
+// Passing Logger returned by Delegating, or derived from it, will set global logger to
+// the logger it currently delegates to.
 func SetLogger(logger Logger) {
-	globalLogger = logger
+	if d, ok := logger.(*delegatingLogger); ok {
+		logger = d.resolve()
+	}
+	globalLogger.Store(&loggerHolder{logger})
 }
 
-// Fatal logs a message at FatalLevel using global logger, then calls os.Exit(1). This should only be use with extra care, ideally Fatal
+// Fatal logs a message at FatalLevel using global logger, then calls Exit(1). This should only be use with extra care, ideally Fatal
 // should only be used in main where the apps encountered an error and have noway to continue.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
@@ -31,6 +45,13 @@ func Fatal(msg string, err error, optfields ...LogField) {
 	GetLogger().Fatal(msg, err, optfields...)
 }
 
+// Panic logs a message at PanicLevel using global logger, then panics with msg.
+// optfields is optional, when supplied it will be added as new field using
+// Key as field name, and Value as it's value.
+func Panic(msg string, err error, optfields ...LogField) {
+	GetLogger().Panic(msg, err, optfields...)
+}
+
 // Error logs a message at ErrorLevel using global logger, put the passed error in "error" field.
 // optfields is optional, when supplied it will be added as new field using
 // Key as field name, and Value as it's value.
@@ -59,8 +80,28 @@ func Debug(msg string, optfields ...LogField) {
 	GetLogger().Debug(msg, optfields...)
 }
 
+// Trace logs a message at TraceLevel using global logger.
+// optfields is optional, when supplied it will be added as new field using
+// Key as field name, and Value as it's value.
+func Trace(msg string, optfields ...LogField) {
+	GetLogger().Trace(msg, optfields...)
+}
+
 // WithContext return Logger instance that will use passed context to log additional info,
-// such as opentelemetry's SpanID and TraceID if applicable.
+// such as opentelemetry's SpanID and TraceID if applicable. The Logger is derived from
+// logger carried by ctx, see FromContext.
 func WithContext(ctx context.Context) Logger {
-	return GetLogger().WithContext(ctx)
+	return FromContext(ctx).WithContext(ctx)
+}
+
+// With return Logger instance derived from global logger that will add passed fields
+// to every log it writes.
+func With(fields ...LogField) Logger {
+	return GetLogger().With(fields...)
+}
+
+// Named return Logger instance derived from global logger with passed name appended
+// to global logger name.
+func Named(name string) Logger {
+	return GetLogger().Named(name)
 }
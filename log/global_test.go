@@ -58,6 +58,19 @@ func TestError(t *testing.T) {
 	assert.Equal(t, "level:error\tmessage:Something went wrong\terror:oopsie\n", suf)
 }
 
+func TestTraceAndPanic(t *testing.T) {
+	observer := &logObserver{}
+	log.SetLogger(log.NewSimpleLogger(observer, log.TraceLogLevel))
+
+	log.Trace("Hello")
+	assert.PanicsWithValue(t, "Something went wrong", func() {
+		log.Panic("Something went wrong", errors.New("oopsie"))
+	})
+	require.Equal(t, 2, len(observer.entries))
+	assert.Equal(t, "level:trace\tmessage:Hello\n", observer.entries[0][39:])
+	assert.Equal(t, "level:panic\tmessage:Something went wrong\terror:oopsie\n", observer.entries[1][39:])
+}
+
 func writeGlobalLog(lv log.LogLevel, fields ...log.LogField) {
 	switch lv {
 	case log.DebugLogLevel:
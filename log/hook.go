@@ -0,0 +1,70 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HookEntry is a log entry passed to Hook.
+type HookEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Name    string
+	Message string
+	Err     error
+	// Fields contains all fields written along with the log, including fields bound
+	// using With and fields extracted from Context.
+	Fields []LogField
+	// Context is the context passed to WithContext, nil if WithContext is never called.
+	Context context.Context
+}
+
+// Hook is called for every log written by logger drivers after the log passes level
+// filtering, ie. to increment a metric or fire an alert when Error or Fatal is logged.
+// Hook is called synchronously by the goroutine writing the log thus it should be fast,
+// it must not write log using the same logger to avoid infinite recursion.
+type Hook func(e HookEntry)
+
+var (
+	hooksMu     sync.RWMutex
+	globalHooks []Hook
+)
+
+// AddHook register hooks to be called by all logger drivers, hooks are called in the
+// same order they are registered, before hooks registered on the logger itself.
+func AddHook(hooks ...Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	globalHooks = append(globalHooks[:len(globalHooks):len(globalHooks)], hooks...)
+}
+
+// SetHooks replace all registered global hooks, calling it without argument will
+// remove all global hooks.
+func SetHooks(hooks ...Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	globalHooks = append([]Hook(nil), hooks...)
+}
+
+// RunHooks call global hooks followed by hooks with entry returned by fn, fn is only
+// called when there is at least one hook to avoid building the entry needlessly.
+// Logger drivers should call RunHooks for every log they write.
+func RunHooks(hooks []Hook, fn func() HookEntry) {
+	hooksMu.RLock()
+	global := globalHooks
+	hooksMu.RUnlock()
+
+	if len(global) == 0 && len(hooks) == 0 {
+		return
+	}
+	e := fn()
+	for _, hook := range global {
+		hook(e)
+	}
+	for _, hook := range hooks {
+		hook(e)
+	}
+}
@@ -0,0 +1,100 @@
+package log_test
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hookRecorder struct {
+	mu      sync.Mutex
+	entries []log.HookEntry
+}
+
+func (r *hookRecorder) hook(e log.HookEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+}
+
+func setGlobalHook(t *testing.T) *hookRecorder {
+	r := &hookRecorder{}
+	log.SetHooks(r.hook)
+	t.Cleanup(func() {
+		log.SetHooks()
+	})
+	return r
+}
+
+func TestSimpleLogger_Hooks(t *testing.T) {
+	global := setGlobalHook(t)
+	local := &hookRecorder{}
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel, log.WithHooks(local.hook))
+
+	err := errors.New("oopsie")
+	logger.Named("payments").With(log.String("requestId", "abc")).Error("Something went wrong", err, log.Int64("status", 500))
+	logger.Debug("Hello")
+
+	require.Equal(t, 1, len(observer.entries))
+	require.Equal(t, 1, len(global.entries))
+	require.Equal(t, 1, len(local.entries))
+	e := global.entries[0]
+	assert.Equal(t, log.ErrorLogLevel, e.Level)
+	assert.Equal(t, "payments", e.Name)
+	assert.Equal(t, "Something went wrong", e.Message)
+	assert.Equal(t, err, e.Err)
+	assert.Equal(t, []log.LogField{log.String("requestId", "abc"), log.Int64("status", 500)}, e.Fields)
+	assert.False(t, e.Time.IsZero())
+	assert.Equal(t, e.Message, local.entries[0].Message)
+}
+
+func TestNoOpLogger_Hooks(t *testing.T) {
+	global := setGlobalHook(t)
+	logger := &log.NoOpLogger{}
+
+	logger.Error("Something went wrong", errors.New("oopsie"), log.String("requestId", "abc"))
+	logger.Info("Hello")
+	require.Equal(t, 2, len(global.entries))
+	assert.Equal(t, log.ErrorLogLevel, global.entries[0].Level)
+	assert.Equal(t, "oopsie", global.entries[0].Err.Error())
+	assert.Equal(t, []log.LogField{log.String("requestId", "abc")}, global.entries[0].Fields)
+	assert.Equal(t, log.InfoLogLevel, global.entries[1].Level)
+}
+
+func TestSlogLogger_Hooks(t *testing.T) {
+	global := setGlobalHook(t)
+	observer := &logObserver{}
+	logger := log.NewSlogLogger(slog.NewTextHandler(observer, nil))
+
+	logger.With(log.String("requestId", "abc")).Warn("Careful", log.Int64("status", 429))
+	logger.Debug("Hello")
+	require.Equal(t, 1, len(global.entries))
+	assert.Equal(t, log.WarnLogLevel, global.entries[0].Level)
+	assert.Equal(t, []log.LogField{log.String("requestId", "abc"), log.Int64("status", 429)}, global.entries[0].Fields)
+}
+
+func TestAddHook(t *testing.T) {
+	var calls []string
+	log.SetHooks(func(log.HookEntry) { calls = append(calls, "first") })
+	log.AddHook(func(log.HookEntry) { calls = append(calls, "second") })
+	t.Cleanup(func() {
+		log.SetHooks()
+	})
+
+	log.RunHooks([]log.Hook{func(log.HookEntry) { calls = append(calls, "local") }}, func() log.HookEntry {
+		return log.HookEntry{}
+	})
+	assert.Equal(t, []string{"first", "second", "local"}, calls)
+
+	log.SetHooks()
+	log.RunHooks(nil, func() log.HookEntry {
+		t.Error("entry should not be built without hooks")
+		return log.HookEntry{}
+	})
+}
@@ -0,0 +1,206 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// AtomicLevel is LogLevel which can be changed safely at runtime, logger sharing the same
+// AtomicLevel will be affected by the change. AtomicLevel also implements http.Handler to
+// allow the level to be changed via HTTP, see ServeHTTP.
+type AtomicLevel struct {
+	v atomic.Int32
+}
+
+// NewAtomicLevel create AtomicLevel with lv as initial level.
+func NewAtomicLevel(lv LogLevel) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(lv)
+	return a
+}
+
+// Level return current LogLevel.
+func (a *AtomicLevel) Level() LogLevel {
+	return LogLevel(a.v.Load())
+}
+
+// SetLevel change current LogLevel.
+func (a *AtomicLevel) SetLevel(lv LogLevel) {
+	a.v.Store(int32(lv))
+}
+
+// Enabled return true if log at lv should be logged.
+func (a *AtomicLevel) Enabled(lv LogLevel) bool {
+	return a.Level() <= lv
+}
+
+// maxLevelPayloadSize limits size of request body accepted by AtomicLevel.ServeHTTP.
+const maxLevelPayloadSize = 1024
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+type levelErrorPayload struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP serves JSON endpoint to get or change current LogLevel. GET request return
+// current level, ie. {"level":"info"}, while PUT request change current level using the
+// same JSON payload and return the new level. Request body larger than 1KB is rejected.
+func (a *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req levelPayload
+		body := http.MaxBytesReader(w, r.Body, maxLevelPayloadSize)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			enc.Encode(levelErrorPayload{Error: fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		lv, err := ParseLogLevel(req.Level)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			enc.Encode(levelErrorPayload{Error: err.Error()})
+			return
+		}
+		a.SetLevel(lv)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		enc.Encode(levelErrorPayload{Error: "only GET and PUT are supported"})
+		return
+	}
+	enc.Encode(levelPayload{Level: a.Level().String()})
+}
+
+// String return lowercase name of the level, ie. "info".
+func (lv LogLevel) String() string {
+	switch lv {
+	case FatalLogLevel:
+		return "fatal"
+	case PanicLogLevel:
+		return "panic"
+	case ErrorLogLevel:
+		return "error"
+	case WarnLogLevel:
+		return "warn"
+	case InfoLogLevel:
+		return "info"
+	case DebugLogLevel:
+		return "debug"
+	case TraceLogLevel:
+		return "trace"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", lv)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, it return error for unknown level.
+func (lv LogLevel) MarshalText() ([]byte, error) {
+	if lv < TraceLogLevel || lv > FatalLogLevel {
+		return nil, fmt.Errorf("unknown level: %d", lv)
+	}
+	return []byte(lv.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler which allows LogLevel to be read from
+// JSON or YAML configuration, see ParseLogLevel for accepted names.
+func (lv *LogLevel) UnmarshalText(text []byte) error {
+	l, err := ParseLogLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*lv = l
+	return nil
+}
+
+// Set implements flag.Value, ie. flag.Var(&level, "log-level", "log level").
+func (lv *LogLevel) Set(s string) error {
+	return lv.UnmarshalText([]byte(s))
+}
+
+// ParseLogLevel parse case-insensitive level name into LogLevel. Beside the name returned
+// by LogLevel.String, it also accepts "warning" and "err" aliases.
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "fatal":
+		return FatalLogLevel, nil
+	case "panic":
+		return PanicLogLevel, nil
+	case "error", "err":
+		return ErrorLogLevel, nil
+	case "warn", "warning":
+		return WarnLogLevel, nil
+	case "info":
+		return InfoLogLevel, nil
+	case "debug":
+		return DebugLogLevel, nil
+	case "trace":
+		return TraceLogLevel, nil
+	default:
+		return 0, fmt.Errorf("unrecognized level: %q", s)
+	}
+}
+
+// LevelOverrides maps logger name prefix to LogLevel, it's used to override root log level
+// for named loggers (see Logger.Named). A prefix matches logger with the same name and all of
+// its descendants, ie. "payments" or "payments.*" matches both "payments" and "payments.db".
+// When multiple prefixes match, the longest one wins.
+type LevelOverrides map[string]LogLevel
+
+// Level return LogLevel for logger with given name, root is returned if no prefix
+// matches the name.
+func (o LevelOverrides) Level(name string, root LogLevel) LogLevel {
+	if lv, ok := o.lookup(name); ok {
+		return lv
+	}
+	return root
+}
+
+func (o LevelOverrides) lookup(name string) (LogLevel, bool) {
+	if len(o) == 0 || name == "" {
+		return 0, false
+	}
+	for {
+		if lv, ok := o[name]; ok {
+			return lv, true
+		}
+		if lv, ok := o[name+".*"]; ok {
+			return lv, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// Min return the lowest LogLevel between root and all overrides.
+func (o LevelOverrides) Min(root LogLevel) LogLevel {
+	min := root
+	for _, lv := range o {
+		if lv < min {
+			min = lv
+		}
+	}
+	return min
+}
+
+func joinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,123 @@
+package log_test
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelOverrides_Level(t *testing.T) {
+	overrides := log.LevelOverrides{
+		"payments.*":    log.DebugLogLevel,
+		"payments.http": log.ErrorLogLevel,
+		"db":            log.WarnLogLevel,
+	}
+
+	tests := []struct {
+		name     string
+		expected log.LogLevel
+	}{
+		{"", log.InfoLogLevel},
+		{"payments", log.DebugLogLevel},
+		{"payments.db", log.DebugLogLevel},
+		{"payments.http", log.ErrorLogLevel},
+		{"payments.http.client", log.ErrorLogLevel},
+		{"paymentsx", log.InfoLogLevel},
+		{"db.pool", log.WarnLogLevel},
+		{"orders", log.InfoLogLevel},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, overrides.Level(tt.name, log.InfoLogLevel), tt.name)
+	}
+	assert.Equal(t, log.DebugLogLevel, overrides.Min(log.InfoLogLevel))
+}
+
+func TestAtomicLevel_ServeHTTP(t *testing.T) {
+	level := log.NewAtomicLevel(log.InfoLogLevel)
+
+	rr := httptest.NewRecorder()
+	level.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"level":"info"}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	level.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, rr.Body.String())
+	assert.Equal(t, log.DebugLogLevel, level.Level())
+
+	rr = httptest.NewRecorder()
+	level.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"verbose"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, log.DebugLogLevel, level.Level())
+
+	rr = httptest.NewRecorder()
+	body := `{"level":"info","padding":"` + strings.Repeat("a", 2048) + `"}`
+	level.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, log.DebugLogLevel, level.Level())
+
+	rr = httptest.NewRecorder()
+	level.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected log.LogLevel
+	}{
+		{"fatal", log.FatalLogLevel},
+		{"ERROR", log.ErrorLogLevel},
+		{"err", log.ErrorLogLevel},
+		{"Warn", log.WarnLogLevel},
+		{"warning", log.WarnLogLevel},
+		{" info ", log.InfoLogLevel},
+		{"debug", log.DebugLogLevel},
+		{"trace", log.TraceLogLevel},
+		{"Panic", log.PanicLogLevel},
+	}
+	for _, tt := range tests {
+		lv, err := log.ParseLogLevel(tt.s)
+		assert.NoError(t, err, tt.s)
+		assert.Equal(t, tt.expected, lv, tt.s)
+	}
+
+	_, err := log.ParseLogLevel("verbose")
+	assert.EqualError(t, err, `unrecognized level: "verbose"`)
+}
+
+func TestLogLevel_Text(t *testing.T) {
+	assert.Equal(t, "warn", log.WarnLogLevel.String())
+	assert.Equal(t, "LogLevel(9)", log.LogLevel(9).String())
+
+	var config struct {
+		Level  log.LogLevel       `json:"level"`
+		Levels log.LevelOverrides `json:"levels"`
+	}
+	err := json.Unmarshal([]byte(`{"level":"WARNING","levels":{"payments":"debug"}}`), &config)
+	require.NoError(t, err)
+	assert.Equal(t, log.WarnLogLevel, config.Level)
+	assert.Equal(t, log.LevelOverrides{"payments": log.DebugLogLevel}, config.Levels)
+
+	b, err := json.Marshal(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"level":"warn","levels":{"payments":"debug"}}`, string(b))
+
+	_, err = json.Marshal(log.LogLevel(9))
+	assert.Error(t, err)
+	assert.Error(t, json.Unmarshal([]byte(`"verbose"`), &config.Level))
+
+	var lv log.LogLevel
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&lv, "log-level", "log level")
+	require.NoError(t, fs.Parse([]string{"-log-level", "err"}))
+	assert.Equal(t, log.ErrorLogLevel, lv)
+}
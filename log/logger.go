@@ -2,32 +2,37 @@ package log
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"log"
-	"os"
 	"time"
 )
 
 type LogLevel int8
 
 const (
-	FatalLogLevel LogLevel = 3
+	FatalLogLevel LogLevel = 4
+	PanicLogLevel LogLevel = 3
 	ErrorLogLevel LogLevel = 2
 	WarnLogLevel  LogLevel = 1
 	InfoLogLevel  LogLevel = 0
 	DebugLogLevel LogLevel = -1
+	TraceLogLevel LogLevel = -2
 )
 
 // Logger log message to underlying logger. Any logging driver should
 // implements Logger interface. The optfield should be optional, and
 // when it's not nil/empty it should be logged as structured key/value.
 type Logger interface {
-	// Fatal logs a message at FatalLevel, then calls os.Exit(1). This should only be use with extra care, ideally Fatal
+	// Fatal logs a message at FatalLevel, then calls Exit(1). This should only be use with extra care, ideally Fatal
 	// should only be used in main where the apps encountered an error and have noway to continue.
 	// optfields is optional, when supplied it will be added as new field using
 	// Key as field name, and Value as it's value.
 	Fatal(msg string, err error, optfields ...LogField)
+	// Panic logs a message at PanicLevel, then panics with msg. Unlike Fatal, the panic can be
+	// recovered, ie. by recoverer middleware.
+	// optfields is optional, when supplied it will be added as new field using
+	// Key as field name, and Value as it's value.
+	Panic(msg string, err error, optfields ...LogField)
 	// Error logs a message at ErrorLevel, put the passed error in "error" field.
 	// optfields is optional, when supplied it will be added as new field using
 	// Key as field name, and Value as it's value.
@@ -44,28 +49,81 @@ type Logger interface {
 	// optfields is optional, when supplied it will be added as new field using
 	// Key as field name, and Value as it's value.
 	Debug(msg string, optfields ...LogField)
+	// Trace logs a message at TraceLevel, it's meant for very chatty diagnostics which are
+	// too verbose for DebugLevel.
+	// optfields is optional, when supplied it will be added as new field using
+	// Key as field name, and Value as it's value.
+	Trace(msg string, optfields ...LogField)
 	// WithContext return Logger instance that will use passed context to log additional info,
 	// such as opentelemetry's SpanID and TraceID if applicable.
 	WithContext(ctx context.Context) Logger
+	// With return Logger instance that will add passed fields to every log it writes,
+	// the fields are written before optfields passed to each log method.
+	With(fields ...LogField) Logger
+	// Named return Logger instance with passed name appended to current logger name,
+	// names are separated by period, ie. Named("payments").Named("db") is named "payments.db".
+	Named(name string) Logger
+}
+
+// LevelEnabler is optionally implemented by Logger which can tell whether log at lv is
+// written, wrappers such as Sampler and SlogHandler use it to skip work for disabled levels.
+type LevelEnabler interface {
+	Enabled(lv LogLevel) bool
 }
 
 var _ Logger = &NoOpLogger{}
 
 // NoOpLogger will not writes out logs to any output. All NoOpLogger method basically doesn't do anything
-// except for Fatal, it will simply call os.Exit(1).
+// except for Fatal and Panic, Fatal will simply call Exit(1) and Panic will panic with msg. Global hooks are still called for every log, see AddHook.
 type NoOpLogger struct{}
 
-// Fatal call os.Exit(1)
+// Fatal call Exit(1)
 func (l *NoOpLogger) Fatal(msg string, err error, optfields ...LogField) {
-	os.Exit(1)
+	runNoOpHooks(FatalLogLevel, msg, err, optfields)
+	Exit(1)
+}
+
+// Panic panics with msg
+func (l *NoOpLogger) Panic(msg string, err error, optfields ...LogField) {
+	runNoOpHooks(PanicLogLevel, msg, err, optfields)
+	panic(msg)
+}
+func (l *NoOpLogger) Error(msg string, err error, optfields ...LogField) {
+	runNoOpHooks(ErrorLogLevel, msg, err, optfields)
+}
+func (l *NoOpLogger) Warn(msg string, optfields ...LogField) {
+	runNoOpHooks(WarnLogLevel, msg, nil, optfields)
+}
+func (l *NoOpLogger) Info(msg string, optfields ...LogField) {
+	runNoOpHooks(InfoLogLevel, msg, nil, optfields)
+}
+func (l *NoOpLogger) Debug(msg string, optfields ...LogField) {
+	runNoOpHooks(DebugLogLevel, msg, nil, optfields)
+}
+func (l *NoOpLogger) Trace(msg string, optfields ...LogField) {
+	runNoOpHooks(TraceLogLevel, msg, nil, optfields)
 }
-func (l *NoOpLogger) Error(msg string, err error, optfields ...LogField) {}
-func (l *NoOpLogger) Warn(msg string, optfields ...LogField)             {}
-func (l *NoOpLogger) Info(msg string, optfields ...LogField)             {}
-func (l *NoOpLogger) Debug(msg string, optfields ...LogField)            {}
 func (l *NoOpLogger) WithContext(ctx context.Context) Logger {
 	return l
 }
+func (l *NoOpLogger) With(fields ...LogField) Logger {
+	return l
+}
+func (l *NoOpLogger) Named(name string) Logger {
+	return l
+}
+
+func runNoOpHooks(lv LogLevel, msg string, err error, optfields []LogField) {
+	RunHooks(nil, func() HookEntry {
+		return HookEntry{
+			Time:    time.Now(),
+			Level:   lv,
+			Message: msg,
+			Err:     err,
+			Fields:  optfields,
+		}
+	})
+}
 
 // WriterFunc takes Logger's log method signature to implement io.Writer,
 // this is useful when you want to use Logger as standard log's output.
@@ -77,28 +135,94 @@ func (w WriterFunc) Write(m []byte) (n int, err error) {
 	return len(m), nil
 }
 
-var _ Logger = &SimpleLogger{}
+var (
+	_ Logger       = &SimpleLogger{}
+	_ LevelEnabler = &SimpleLogger{}
+)
 
 type SimpleLogger struct {
-	l  *log.Logger
-	lv LogLevel
+	l         *log.Logger
+	level     *AtomicLevel
+	overrides LevelOverrides
+	name      string
+	// lv is used instead of level when the logger name matches overrides.
+	lv         LogLevel
+	overridden bool
+	fields     []LogField
+	encoder    Encoder
+	redactor   *Redactor
+	errdetails ErrorDetails
+	hooks      []Hook
+	ctx        context.Context
+}
+
+// SimpleLoggerOption configure optional behaviour of SimpleLogger.
+type SimpleLoggerOption func(*SimpleLogger)
+
+// WithLevelOverrides set LevelOverrides to use by named loggers derived from SimpleLogger,
+// any named logger which doesn't match the overrides will use root log level.
+func WithLevelOverrides(overrides LevelOverrides) SimpleLoggerOption {
+	return func(l *SimpleLogger) {
+		l.overrides = overrides
+	}
+}
+
+// WithEncoder set Encoder to use by SimpleLogger, default to TextEncoder.
+func WithEncoder(encoder Encoder) SimpleLoggerOption {
+	return func(l *SimpleLogger) {
+		l.encoder = encoder
+	}
+}
+
+// WithRedaction set RedactionPolicy to apply to error and fields before they are encoded.
+func WithRedaction(policy RedactionPolicy) SimpleLoggerOption {
+	return func(l *SimpleLogger) {
+		l.redactor = NewRedactor(policy)
+	}
+}
+
+// WithErrorDetails set which details of logged error are rendered per level, see ErrorDetails.
+func WithErrorDetails(details ErrorDetails) SimpleLoggerOption {
+	return func(l *SimpleLogger) {
+		l.errdetails = details
+	}
+}
+
+// WithHooks add hooks to be called for every log written by SimpleLogger and loggers
+// derived from it, the hooks are called after global hooks, see AddHook.
+func WithHooks(hooks ...Hook) SimpleLoggerOption {
+	return func(l *SimpleLogger) {
+		l.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], hooks...)
+	}
 }
 
-func NewSimpleLogger(w io.Writer, lv LogLevel) *SimpleLogger {
+// NewSimpleLogger create SimpleLogger that writes to w, any log lower than lv will not be logged.
+// If w is nil, standard log default writer will be used.
+func NewSimpleLogger(w io.Writer, lv LogLevel, opts ...SimpleLoggerOption) *SimpleLogger {
 	if w == nil {
 		w = log.Default().Writer()
 	}
-	l := log.New(w, "", 0)
-	return &SimpleLogger{
-		l:  l,
-		lv: lv,
+	l := &SimpleLogger{
+		l:     log.New(w, "", 0),
+		level: NewAtomicLevel(lv),
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
+	return l
 }
 
-// Fatal call os.Exit(1)
+// Fatal writes log at FatalLogLevel then call Exit(1).
 func (l *SimpleLogger) Fatal(msg string, err error, optfields ...LogField) {
 	l.writeLog(FatalLogLevel, msg, err, optfields...)
-	os.Exit(1)
+	Exit(1)
+}
+
+// Panic writes log at PanicLogLevel then panics with msg, it panics even when
+// PanicLogLevel is not enabled.
+func (l *SimpleLogger) Panic(msg string, err error, optfields ...LogField) {
+	l.writeLog(PanicLogLevel, msg, err, optfields...)
+	panic(msg)
 }
 
 func (l *SimpleLogger) Error(msg string, err error, optfields ...LogField) {
@@ -117,48 +241,91 @@ func (l *SimpleLogger) Debug(msg string, optfields ...LogField) {
 	l.writeLog(DebugLogLevel, msg, nil, optfields...)
 }
 
+func (l *SimpleLogger) Trace(msg string, optfields ...LogField) {
+	l.writeLog(TraceLogLevel, msg, nil, optfields...)
+}
+
+// WithContext return new SimpleLogger which writes fields extracted from ctx
+// using registered ContextExtractor, see AddContextExtractor.
 func (l *SimpleLogger) WithContext(ctx context.Context) Logger {
-	return l
+	l2 := *l
+	l2.ctx = ctx
+	return &l2
 }
 
-func (l *SimpleLogger) writeLog(lv LogLevel, msg string, err error, optfields ...LogField) {
-	if l.lv > lv {
-		return
+// With return new SimpleLogger that share the same writer and level,
+// and will write passed fields in every log.
+func (l *SimpleLogger) With(fields ...LogField) Logger {
+	if len(fields) == 0 {
+		return l
 	}
+	l2 := *l
+	l2.fields = make([]LogField, 0, len(l.fields)+len(fields))
+	l2.fields = append(l2.fields, l.fields...)
+	l2.fields = append(l2.fields, l.redactor.RedactFields(fields)...)
+	return &l2
+}
 
-	tf := "2006-01-02T15:04:05.000Z0700"
-	now := time.Now()
-	var b []byte
-	b = append(b, "timestamp:"...)
-	b = now.AppendFormat(b, tf)
-	b = append(b, "\tlevel:"...)
-
-	switch lv {
-	case FatalLogLevel:
-		b = append(b, "fatal"...)
-	case ErrorLogLevel:
-		b = append(b, "error"...)
-	case WarnLogLevel:
-		b = append(b, "warn"...)
-	case InfoLogLevel:
-		b = append(b, "info"...)
-	default:
-		b = append(b, "debug"...)
-	}
+// Named return new SimpleLogger with passed name appended to current logger name,
+// the log level is resolved using LevelOverrides if configured.
+func (l *SimpleLogger) Named(name string) Logger {
+	l2 := *l
+	l2.name = joinName(l.name, name)
+	l2.lv, l2.overridden = l.overrides.lookup(l2.name)
+	return &l2
+}
+
+// Level return AtomicLevel used by SimpleLogger and all loggers derived from it,
+// changing the level will affect all of them except named loggers which level
+// is overridden by LevelOverrides.
+func (l *SimpleLogger) Level() *AtomicLevel {
+	return l.level
+}
 
-	b = append(b, "\tmessage:"...)
-	b = append(b, msg...)
+// Enabled return true if log at lv is written, using LevelOverrides for named logger
+// which level is overridden.
+func (l *SimpleLogger) Enabled(lv LogLevel) bool {
+	if l.overridden {
+		return l.lv <= lv
+	}
+	return l.level.Enabled(lv)
+}
 
-	if err != nil {
-		b = append(b, "\terror:"...)
-		b = append(b, err.Error()...)
+func (l *SimpleLogger) writeLog(lv LogLevel, msg string, err error, optfields ...LogField) {
+	if !l.Enabled(lv) {
+		return
 	}
 
-	for _, field := range optfields {
-		b = append(b, '\t')
-		b = append(b, field.Key...)
-		b = append(b, ':')
-		b = append(b, fmt.Sprint(field.Value)...)
+	e := entry{
+		time:      time.Now(),
+		level:     lv,
+		name:      l.name,
+		msg:       msg,
+		err:       l.redactor.RedactError(err),
+		errfields: l.redactor.RedactFields(l.errdetails.AppendFields(nil, lv, err)),
+		fields:    l.fields,
+		optfields: l.redactor.RedactFields(optfields),
+	}
+	if l.ctx != nil {
+		e.ctxfields = l.redactor.RedactFields(FieldsFromContext(l.ctx))
 	}
+	b := l.encoder.appendEntry(nil, &e)
 	l.l.Println(string(b))
+
+	RunHooks(l.hooks, func() HookEntry {
+		fields := make([]LogField, 0, len(e.errfields)+len(e.fields)+len(e.optfields)+len(e.ctxfields))
+		fields = append(fields, e.errfields...)
+		fields = append(fields, e.fields...)
+		fields = append(fields, e.optfields...)
+		fields = append(fields, e.ctxfields...)
+		return HookEntry{
+			Time:    e.time,
+			Level:   lv,
+			Name:    l.name,
+			Message: msg,
+			Err:     e.err,
+			Fields:  fields,
+			Context: l.ctx,
+		}
+	})
 }
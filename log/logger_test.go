@@ -2,10 +2,13 @@ package log_test
 
 import (
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hexastack-dev/devkit-go/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func BenchmarkLogger(b *testing.B) {
@@ -25,6 +28,11 @@ func BenchmarkLogger(b *testing.B) {
 	b.Run("log error with 10 fields", func(b *testing.B) {
 		testError(b, logger)
 	})
+
+	b.ResetTimer()
+	b.Run("log with 10 typed fields", func(b *testing.B) {
+		testLogWithTypedArguments(b, logger)
+	})
 }
 
 func testLog(b *testing.B, logger log.Logger) {
@@ -61,6 +69,27 @@ func generateField(i int) []log.LogField {
 	}
 }
 
+func testLogWithTypedArguments(b *testing.B, logger log.Logger) {
+	for i := 0; i < b.N; i++ {
+		logger.Info("info message", generateTypedField(i)...)
+	}
+}
+
+func generateTypedField(i int) []log.LogField {
+	return []log.LogField{
+		log.String("a", "1"),
+		log.String("b", "2"),
+		log.String("c", "3"),
+		log.Int64("d", 4),
+		log.Int64("e", int64(i)),
+		log.Bool("f", true),
+		log.Duration("g", time.Second),
+		log.String("h", "s3"),
+		log.String("i", "s4"),
+		log.String("j", "s5"),
+	}
+}
+
 type logObserver struct {
 	entries []string
 }
@@ -70,6 +99,24 @@ func (l *logObserver) Write(m []byte) (n int, err error) {
 	return len(m), nil
 }
 
+// syncLogObserver is logObserver which can be written from multiple goroutines.
+type syncLogObserver struct {
+	mu sync.Mutex
+	logObserver
+}
+
+func (l *syncLogObserver) Write(m []byte) (n int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.logObserver.Write(m)
+}
+
+func (l *syncLogObserver) Entries() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.entries...)
+}
+
 func TestSimpleLogger_Debug(t *testing.T) {
 	observer := &logObserver{}
 	logger := log.NewSimpleLogger(observer, log.DebugLogLevel)
@@ -115,8 +162,124 @@ func TestSimpleLogger_Error(t *testing.T) {
 	assert.Equal(t, "level:error\tmessage:Something went wrong\terror:oopsie\n", suf)
 }
 
+func TestSimpleLogger_Trace(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.DebugLogLevel)
+
+	writeLog(logger, log.TraceLogLevel)
+	assert.Equal(t, 0, len(observer.entries))
+
+	logger.Level().SetLevel(log.TraceLogLevel)
+	writeLog(logger, log.TraceLogLevel)
+	assert.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:trace\tmessage:Hello\n", suf)
+}
+
+func TestSimpleLogger_Panic(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+
+	assert.PanicsWithValue(t, "Something went wrong", func() {
+		logger.Panic("Something went wrong", errors.New("oopsie"))
+	})
+	assert.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:panic\tmessage:Something went wrong\terror:oopsie\n", suf)
+
+	assert.PanicsWithValue(t, "Something went wrong", func() {
+		(&log.NoOpLogger{}).Panic("Something went wrong", nil)
+	})
+}
+
+func TestSimpleLogger_With(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel).With(log.Field("requestId", "abc"))
+
+	writeLog(logger.With(log.Field("tenant", "t1")), log.InfoLogLevel, log.Field("v1", "value1"))
+	writeLog(logger, log.InfoLogLevel)
+	require.Equal(t, 2, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:info\tmessage:Hello\trequestId:abc\ttenant:t1\tv1:value1\n", suf)
+	suf = observer.entries[1][39:]
+	assert.Equal(t, "level:info\tmessage:Hello\trequestId:abc\n", suf)
+}
+
+func TestSimpleLogger_Named(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.WarnLogLevel, log.WithLevelOverrides(log.LevelOverrides{
+		"payments.*": log.DebugLogLevel,
+	}))
+
+	writeLog(logger.Named("orders"), log.InfoLogLevel)
+	writeLog(logger.Named("payments").Named("db"), log.DebugLogLevel)
+	require.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:debug\tlogger:payments.db\tmessage:Hello\n", suf)
+}
+
+func TestSimpleLogger_Level(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+	child := logger.With(log.Field("requestId", "abc"))
+
+	writeLog(child, log.DebugLogLevel)
+	logger.Level().SetLevel(log.DebugLogLevel)
+	writeLog(child, log.DebugLogLevel)
+	require.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:debug\tmessage:Hello\trequestId:abc\n", suf)
+}
+
+func TestSimpleLogger_TypedFields(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+
+	ts := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+	writeLog(logger, log.InfoLogLevel,
+		log.String("s", "value"),
+		log.Int64("i", 42),
+		log.Bool("b", true),
+		log.Duration("d", time.Second),
+		log.Time("t", ts),
+		log.Err("e", errors.New("oopsie")),
+		log.Stringer("st", time.March),
+		log.Object("o", []int{1, 2}),
+	)
+	require.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	suf := observer.entries[0][39:]
+	assert.Equal(t, "level:info\tmessage:Hello\ts:value\ti:42\tb:true\td:1s\tt:2023-02-01T10:00:00Z\te:oopsie\tst:March\to:[1 2]\n", suf)
+}
+
+// ptrStringer panics when String is called on nil pointer.
+type ptrStringer struct {
+	s string
+}
+
+func (p *ptrStringer) String() string {
+	return p.s
+}
+
+func TestSimpleLogger_NilStringer(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel)
+
+	var typedNil *ptrStringer
+	writeLog(logger, log.InfoLogLevel, log.Stringer("typed", typedNil), log.Stringer("nil", nil))
+	require.Equal(t, 1, len(observer.entries))
+	assert.Equal(t, "level:info\tmessage:Hello\ttyped:<nil>\tnil:<nil>\n", observer.entries[0][39:])
+}
+
 func writeLog(logger log.Logger, lv log.LogLevel, fields ...log.LogField) {
 	switch lv {
+	case log.TraceLogLevel:
+		logger.Trace("Hello", fields...)
 	case log.DebugLogLevel:
 		logger.Debug("Hello", fields...)
 	case log.InfoLogLevel:
@@ -153,4 +316,9 @@ func BenchmarkSimpleLogger(b *testing.B) {
 	b.Run("log error with 10 fields", func(b *testing.B) {
 		testError(b, logger)
 	})
+
+	b.ResetTimer()
+	b.Run("log with 10 typed fields", func(b *testing.B) {
+		testLogWithTypedArguments(b, logger)
+	})
 }
@@ -0,0 +1,10 @@
+// This package provide in-memory Logger which records every log entry, it's intended
+// to be used in tests to assert what has been logged. Use SetGlobal to replace global
+// logger for the duration of a test, ie. when the code under test use global logger:
+//
+//	func TestSomething(t *testing.T) {
+//		logger := logtest.SetGlobal(t)
+//		...
+//		logger.AssertLogged(t, log.ErrorLogLevel, "Something went wrong")
+//	}
+package logtest
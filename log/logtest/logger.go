@@ -0,0 +1,271 @@
+package logtest
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+)
+
+// Entry is a recorded log entry.
+type Entry struct {
+	Time    time.Time
+	Level   log.LogLevel
+	Name    string
+	Message string
+	Err     error
+	// Fields contains fields bound using With followed by optfields passed to log method.
+	Fields []log.LogField
+	// Context is the context passed to WithContext, nil if WithContext is never called.
+	Context context.Context
+	// ContextFields contains fields extracted from Context using registered log.ContextExtractor.
+	ContextFields []log.LogField
+}
+
+// Field lookup field with given key in Fields followed by ContextFields,
+// return the first field found and true, or empty LogField and false otherwise.
+func (e Entry) Field(key string) (log.LogField, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	for _, f := range e.ContextFields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return log.LogField{}, false
+}
+
+// FieldValue return value of field with given key regardless of its kind, see log.LogField.Any.
+// Return nil if the field is not found.
+func (e Entry) FieldValue(key string) any {
+	if f, ok := e.Field(key); ok {
+		return f.Any()
+	}
+	return nil
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+var _ log.Logger = &Logger{}
+
+// Logger implements log.Logger which records every log entry in memory, loggers derived
+// using With, Named or WithContext share the same records. All levels are recorded, and
+// Fatal only records the entry without exiting the program. Logger is safe to be used
+// from multiple goroutines.
+type Logger struct {
+	rec    *recorder
+	name   string
+	fields []log.LogField
+	ctx    context.Context
+}
+
+// New create Logger with empty records.
+func New() *Logger {
+	return &Logger{rec: &recorder{}}
+}
+
+// Fatal records entry at FatalLogLevel, it doesn't exit the program.
+func (l *Logger) Fatal(msg string, err error, optfields ...log.LogField) {
+	l.record(log.FatalLogLevel, msg, err, optfields)
+}
+
+// Panic records entry at PanicLogLevel then panics with msg.
+func (l *Logger) Panic(msg string, err error, optfields ...log.LogField) {
+	l.record(log.PanicLogLevel, msg, err, optfields)
+	panic(msg)
+}
+
+func (l *Logger) Error(msg string, err error, optfields ...log.LogField) {
+	l.record(log.ErrorLogLevel, msg, err, optfields)
+}
+
+func (l *Logger) Warn(msg string, optfields ...log.LogField) {
+	l.record(log.WarnLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Info(msg string, optfields ...log.LogField) {
+	l.record(log.InfoLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Debug(msg string, optfields ...log.LogField) {
+	l.record(log.DebugLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) Trace(msg string, optfields ...log.LogField) {
+	l.record(log.TraceLogLevel, msg, nil, optfields)
+}
+
+func (l *Logger) WithContext(ctx context.Context) log.Logger {
+	l2 := *l
+	l2.ctx = ctx
+	return &l2
+}
+
+func (l *Logger) With(fields ...log.LogField) log.Logger {
+	l2 := *l
+	l2.fields = make([]log.LogField, 0, len(l.fields)+len(fields))
+	l2.fields = append(l2.fields, l.fields...)
+	l2.fields = append(l2.fields, fields...)
+	return &l2
+}
+
+func (l *Logger) Named(name string) log.Logger {
+	l2 := *l
+	if l.name == "" {
+		l2.name = name
+	} else if name != "" {
+		l2.name = l.name + "." + name
+	}
+	return &l2
+}
+
+func (l *Logger) record(lv log.LogLevel, msg string, err error, optfields []log.LogField) {
+	e := Entry{
+		Time:    time.Now(),
+		Level:   lv,
+		Name:    l.name,
+		Message: msg,
+		Err:     err,
+		Context: l.ctx,
+	}
+	e.Fields = make([]log.LogField, 0, len(l.fields)+len(optfields))
+	e.Fields = append(e.Fields, l.fields...)
+	e.Fields = append(e.Fields, optfields...)
+	if l.ctx != nil {
+		e.ContextFields = log.FieldsFromContext(l.ctx)
+	}
+
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = append(l.rec.entries, e)
+}
+
+// Entries return copy of all recorded entries.
+func (l *Logger) Entries() []Entry {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return append([]Entry(nil), l.rec.entries...)
+}
+
+// Len return the number of recorded entries.
+func (l *Logger) Len() int {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return len(l.rec.entries)
+}
+
+// Reset remove all recorded entries.
+func (l *Logger) Reset() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = nil
+}
+
+// Filter return recorded entries which satisfy fn.
+func (l *Logger) Filter(fn func(Entry) bool) []Entry {
+	var entries []Entry
+	for _, e := range l.Entries() {
+		if fn(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// FilterLevel return recorded entries at lv.
+func (l *Logger) FilterLevel(lv log.LogLevel) []Entry {
+	return l.Filter(func(e Entry) bool {
+		return e.Level == lv
+	})
+}
+
+// FilterMessage return recorded entries with message equals msg.
+func (l *Logger) FilterMessage(msg string) []Entry {
+	return l.Filter(func(e Entry) bool {
+		return e.Message == msg
+	})
+}
+
+// FilterField return recorded entries which have field with given key and value, the field
+// value returned by log.LogField.Any is compared using reflect.DeepEqual. Integers of any
+// kind are compared by their value, ie. log.Int64("status", 200) matches 200.
+func (l *Logger) FilterField(key string, value any) []Entry {
+	value = normalizeInt(value)
+	return l.Filter(func(e Entry) bool {
+		f, ok := e.Field(key)
+		return ok && reflect.DeepEqual(normalizeInt(f.Any()), value)
+	})
+}
+
+// normalizeInt convert integer v into int64, or uint64 if it overflows int64.
+func normalizeInt(v any) any {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return u
+		}
+		return int64(rv.Uint())
+	default:
+		return v
+	}
+}
+
+// AssertLogged assert that at least one entry at lv with message equals msg is recorded,
+// return the first matching entry. The test is marked as failed if none is found.
+func (l *Logger) AssertLogged(t testing.TB, lv log.LogLevel, msg string) Entry {
+	t.Helper()
+	for _, e := range l.Entries() {
+		if e.Level == lv && e.Message == msg {
+			return e
+		}
+	}
+	t.Errorf("expected log at level %s with message %q, got %d entries: %v", lv.String(), msg, l.Len(), l.messages())
+	return Entry{}
+}
+
+// AssertNotLogged assert that no entry at lv with message equals msg is recorded.
+func (l *Logger) AssertNotLogged(t testing.TB, lv log.LogLevel, msg string) {
+	t.Helper()
+	for _, e := range l.Entries() {
+		if e.Level == lv && e.Message == msg {
+			t.Errorf("unexpected log at level %s with message %q", lv.String(), msg)
+			return
+		}
+	}
+}
+
+func (l *Logger) messages() []string {
+	entries := l.Entries()
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+// SetGlobal create Logger and set it as global logger using log.SetLogger, the previous
+// global logger is restored when the test and all its subtests complete. Since global
+// logger is shared, SetGlobal must not be used in parallel tests.
+func SetGlobal(t testing.TB) *Logger {
+	t.Helper()
+	prev := log.GetLogger()
+	l := New()
+	log.SetLogger(l)
+	t.Cleanup(func() {
+		log.SetLogger(prev)
+	})
+	return l
+}
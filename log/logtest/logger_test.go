@@ -0,0 +1,98 @@
+package logtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestLogger(t *testing.T) {
+	logger := logtest.New()
+	err := errors.New("oopsie")
+
+	logger.Named("payments").With(log.String("requestId", "abc")).Error("Payment failed", err, log.Int64("status", 500))
+	logger.Info("Payment received", log.String("requestId", "def"))
+	logger.Debug("Payment detail")
+	logger.Fatal("Payment fatal", err)
+
+	require.Equal(t, 4, logger.Len())
+	e := logger.AssertLogged(t, log.ErrorLogLevel, "Payment failed")
+	assert.Equal(t, "payments", e.Name)
+	assert.Equal(t, err, e.Err)
+	assert.Equal(t, "abc", e.FieldValue("requestId"))
+	assert.Equal(t, int64(500), e.FieldValue("status"))
+	assert.Nil(t, e.FieldValue("missing"))
+	assert.False(t, e.Time.IsZero())
+
+	logger.AssertNotLogged(t, log.WarnLogLevel, "Payment failed")
+	assert.Len(t, logger.FilterLevel(log.FatalLogLevel), 1)
+	assert.Len(t, logger.FilterMessage("Payment received"), 1)
+	assert.Len(t, logger.FilterField("requestId", "def"), 1)
+	assert.Len(t, logger.FilterField("status", 500), 1)
+	assert.Len(t, logger.FilterField("status", uint16(500)), 1)
+	assert.Len(t, logger.Filter(func(e logtest.Entry) bool { return e.Err != nil }), 2)
+
+	logger.Reset()
+	assert.Equal(t, 0, logger.Len())
+	assert.Empty(t, logger.Entries())
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	log.SetContextExtractors(func(ctx context.Context) []log.LogField {
+		if v, ok := ctx.Value(ctxKey{}).(string); ok {
+			return []log.LogField{log.String("userId", v)}
+		}
+		return nil
+	})
+	t.Cleanup(func() {
+		log.SetContextExtractors(log.RequestIDExtractor)
+	})
+
+	logger := logtest.New()
+	ctx := context.WithValue(context.Background(), ctxKey{}, "user-1")
+	logger.WithContext(ctx).Warn("Quota almost exceeded")
+
+	e := logger.AssertLogged(t, log.WarnLogLevel, "Quota almost exceeded")
+	assert.Equal(t, ctx, e.Context)
+	assert.Empty(t, e.Fields)
+	assert.Equal(t, "user-1", e.FieldValue("userId"))
+}
+
+func TestLogger_AssertFailure(t *testing.T) {
+	logger := logtest.New()
+	logger.Info("Hello")
+
+	mock := &testing.T{}
+	logger.AssertLogged(mock, log.InfoLogLevel, "Bye")
+	assert.True(t, mock.Failed())
+
+	mock = &testing.T{}
+	logger.AssertNotLogged(mock, log.InfoLogLevel, "Hello")
+	assert.True(t, mock.Failed())
+}
+
+func TestSetGlobal(t *testing.T) {
+	prev := log.GetLogger()
+	t.Run("global", func(t *testing.T) {
+		logger := logtest.SetGlobal(t)
+		log.Error("Something went wrong", errors.New("oopsie"))
+		logger.AssertLogged(t, log.ErrorLogLevel, "Something went wrong")
+	})
+	assert.Equal(t, prev, log.GetLogger())
+}
+
+func TestLogger_FilterFieldUncomparable(t *testing.T) {
+	logger := logtest.New()
+	logger.Info("Hello", log.Object("ids", []int{1, 2}), log.Field("tags", map[string]string{"env": "prod"}))
+
+	assert.Len(t, logger.FilterField("ids", []int{1, 2}), 1)
+	assert.Empty(t, logger.FilterField("ids", []int{1}))
+	assert.Len(t, logger.FilterField("tags", map[string]string{"env": "prod"}), 1)
+}
@@ -0,0 +1,37 @@
+// Package otelctx provides log.ContextExtractor for OpenTelemetry span information, it's
+// a separate module so the log module doesn't depend on OpenTelemetry.
+//
+// Register the extractor to enrich logs written by logger returned from WithContext:
+//
+//	log.AddContextExtractor(otelctx.TraceContextExtractor)
+//
+// zaplog driver always writes span information, thus it doesn't need the extractor.
+//
+// SpanEvents wraps log.Logger to record errors and warnings on the span in the context.
+package otelctx
+
+import (
+	"context"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceContextExtractor extract OpenTelemetry's span information from ctx as
+// "spanId", "traceId" and "traceFlags" fields when the span is recording.
+func TraceContextExtractor(ctx context.Context) []log.LogField {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+	sc := span.SpanContext()
+	fields := make([]log.LogField, 0, 3)
+	if sc.HasSpanID() {
+		fields = append(fields, log.String("spanId", sc.SpanID().String()))
+	}
+	if sc.HasTraceID() {
+		fields = append(fields, log.String("traceId", sc.TraceID().String()))
+	}
+	fields = append(fields, log.Int64("traceFlags", int64(sc.TraceFlags())))
+	return fields
+}
@@ -0,0 +1,42 @@
+package otelctx_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/otelctx"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTraceContextExtractor(t *testing.T) {
+	ctx, span := trace.NewTracerProvider().Tracer("").Start(context.Background(), "testWithContext")
+	defer span.End()
+
+	sc := span.SpanContext()
+	assert.Equal(t, []log.LogField{
+		log.String("spanId", sc.SpanID().String()),
+		log.String("traceId", sc.TraceID().String()),
+		log.Int64("traceFlags", 1),
+	}, otelctx.TraceContextExtractor(ctx))
+	assert.Nil(t, otelctx.TraceContextExtractor(context.Background()))
+}
+
+func TestSimpleLogger_WithContext(t *testing.T) {
+	log.AddContextExtractor(otelctx.TraceContextExtractor)
+	t.Cleanup(func() {
+		log.SetContextExtractors(log.RequestIDExtractor)
+	})
+	ctx, span := trace.NewTracerProvider().Tracer("").Start(context.Background(), "testWithContext")
+	defer span.End()
+
+	var buf bytes.Buffer
+	log.NewSimpleLogger(&buf, log.InfoLogLevel).WithContext(ctx).Info("Hello", log.Field("v1", "value1"))
+
+	sc := span.SpanContext()
+	assert.True(t, strings.HasSuffix(buf.String(),
+		"\tmessage:Hello\tv1:value1\tspanId:"+sc.SpanID().String()+"\ttraceId:"+sc.TraceID().String()+"\ttraceFlags:1\n"), buf.String())
+}
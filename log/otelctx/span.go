@@ -0,0 +1,175 @@
+package otelctx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanEventsConfig define configurations for SpanEvents.
+type SpanEventsConfig struct {
+	// Redaction define policy to mask sensitive error and field values before they are
+	// recorded on the span. Redaction is disabled when nil.
+	Redaction *log.RedactionPolicy
+}
+
+var _ log.Logger = &SpanEvents{}
+
+// SpanEvents wraps log.Logger to also record logs on the span in the context passed to
+// WithContext, so trace views show failures without cross-referencing logs. Error, Panic
+// and Fatal logs are recorded using span.RecordError and set the span status to Error, Warn
+// logs are added as span events, other logs are only written by the wrapped logger.
+// Nothing is recorded when the span is not recording.
+type SpanEvents struct {
+	logger   log.Logger
+	ctx      context.Context
+	fields   []log.LogField
+	redactor *log.Redactor
+}
+
+// NewSpanEvents create SpanEvents which wraps logger.
+func NewSpanEvents(logger log.Logger, config SpanEventsConfig) *SpanEvents {
+	s := &SpanEvents{logger: logger}
+	if config.Redaction != nil {
+		s.redactor = log.NewRedactor(*config.Redaction)
+	}
+	return s
+}
+
+func (s *SpanEvents) Fatal(msg string, err error, optfields ...log.LogField) {
+	s.recordError(log.FatalLogLevel, msg, err, optfields)
+	s.logger.Fatal(msg, err, optfields...)
+}
+
+func (s *SpanEvents) Panic(msg string, err error, optfields ...log.LogField) {
+	s.recordError(log.PanicLogLevel, msg, err, optfields)
+	s.logger.Panic(msg, err, optfields...)
+}
+
+func (s *SpanEvents) Error(msg string, err error, optfields ...log.LogField) {
+	s.recordError(log.ErrorLogLevel, msg, err, optfields)
+	s.logger.Error(msg, err, optfields...)
+}
+
+func (s *SpanEvents) Warn(msg string, optfields ...log.LogField) {
+	if span := s.span(); span != nil {
+		span.AddEvent(msg, trace.WithAttributes(s.attributes(log.WarnLogLevel, optfields)...))
+	}
+	s.logger.Warn(msg, optfields...)
+}
+
+func (s *SpanEvents) Info(msg string, optfields ...log.LogField) {
+	s.logger.Info(msg, optfields...)
+}
+
+func (s *SpanEvents) Debug(msg string, optfields ...log.LogField) {
+	s.logger.Debug(msg, optfields...)
+}
+
+func (s *SpanEvents) Trace(msg string, optfields ...log.LogField) {
+	s.logger.Trace(msg, optfields...)
+}
+
+// WithContext return SpanEvents which wraps logger returned by underlying WithContext
+// and records logs on the span in ctx.
+func (s *SpanEvents) WithContext(ctx context.Context) log.Logger {
+	s2 := *s
+	s2.logger = s.logger.WithContext(ctx)
+	s2.ctx = ctx
+	return &s2
+}
+
+// With return SpanEvents which wraps logger returned by underlying With, the fields
+// are also recorded on the span.
+func (s *SpanEvents) With(fields ...log.LogField) log.Logger {
+	s2 := *s
+	s2.logger = s.logger.With(fields...)
+	s2.fields = make([]log.LogField, 0, len(s.fields)+len(fields))
+	s2.fields = append(s2.fields, s.fields...)
+	s2.fields = append(s2.fields, fields...)
+	return &s2
+}
+
+// Named return SpanEvents which wraps logger returned by underlying Named.
+func (s *SpanEvents) Named(name string) log.Logger {
+	s2 := *s
+	s2.logger = s.logger.Named(name)
+	return &s2
+}
+
+func (s *SpanEvents) span() trace.Span {
+	if s.ctx == nil {
+		return nil
+	}
+	span := trace.SpanFromContext(s.ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+	return span
+}
+
+func (s *SpanEvents) recordError(lv log.LogLevel, msg string, err error, optfields []log.LogField) {
+	span := s.span()
+	if span == nil {
+		return
+	}
+	attrs := s.attributes(lv, optfields)
+	if err != nil {
+		span.RecordError(s.redactor.RedactError(err), trace.WithAttributes(append(attrs, attribute.String("log.message", msg))...))
+	} else {
+		span.AddEvent(msg, trace.WithAttributes(attrs...))
+	}
+	span.SetStatus(codes.Error, msg)
+}
+
+// attributes convert bound fields and optfields into span attributes, "log.severity" is added
+// so events can be distinguished from events added by the instrumentation.
+func (s *SpanEvents) attributes(lv log.LogLevel, optfields []log.LogField) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(s.fields)+len(optfields)+2)
+	attrs = append(attrs, attribute.String("log.severity", lv.String()))
+	for _, field := range s.redactor.RedactFields(s.fields) {
+		attrs = append(attrs, fieldToAttribute(field))
+	}
+	for _, field := range s.redactor.RedactFields(optfields) {
+		attrs = append(attrs, fieldToAttribute(field))
+	}
+	return attrs
+}
+
+func fieldToAttribute(field log.LogField) attribute.KeyValue {
+	switch field.Kind {
+	case log.StringKind:
+		return attribute.String(field.Key, field.Str)
+	case log.Int64Kind:
+		return attribute.Int64(field.Key, field.Int)
+	case log.BoolKind:
+		return attribute.Bool(field.Key, field.Int == 1)
+	case log.DurationKind:
+		return attribute.String(field.Key, time.Duration(field.Int).String())
+	case log.TimeKind:
+		t, _ := field.Any().(time.Time)
+		return attribute.String(field.Key, t.Format(time.RFC3339Nano))
+	default:
+		switch v := field.Any().(type) {
+		case string:
+			return attribute.String(field.Key, v)
+		case []string:
+			return attribute.StringSlice(field.Key, v)
+		case int:
+			return attribute.Int(field.Key, v)
+		case int64:
+			return attribute.Int64(field.Key, v)
+		case float64:
+			return attribute.Float64(field.Key, v)
+		case bool:
+			return attribute.Bool(field.Key, v)
+		default:
+			return attribute.String(field.Key, fmt.Sprint(v))
+		}
+	}
+}
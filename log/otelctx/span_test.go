@@ -0,0 +1,85 @@
+package otelctx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
+	"github.com/hexastack-dev/devkit-go/log/otelctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("").Start(context.Background(), "testSpanEvents")
+
+	tl := logtest.New()
+	logger := otelctx.NewSpanEvents(tl, otelctx.SpanEventsConfig{
+		Redaction: &log.RedactionPolicy{Keys: []string{"password"}},
+	}).With(log.String("requestId", "r1")).WithContext(ctx)
+
+	logger.Info("info is not recorded")
+	logger.Warn("slow query", log.Int64("elapsedMs", 1200))
+	logger.Error("query failed", errors.New("connection reset"), log.String("password", "secret"))
+	span.End()
+
+	assert.Equal(t, 3, tl.Len())
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "query failed", spans[0].Status().Description)
+
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "slow query", events[0].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("log.severity", "warn"),
+		attribute.String("requestId", "r1"),
+		attribute.Int64("elapsedMs", 1200),
+	}, events[0].Attributes)
+
+	assert.Equal(t, "exception", events[1].Name)
+	attrs := attribute.NewSet(events[1].Attributes...)
+	v, _ := attrs.Value("exception.message")
+	assert.Equal(t, "connection reset", v.AsString())
+	v, _ = attrs.Value("log.message")
+	assert.Equal(t, "query failed", v.AsString())
+	v, _ = attrs.Value("log.severity")
+	assert.Equal(t, "error", v.AsString())
+	v, _ = attrs.Value("password")
+	assert.Equal(t, "[REDACTED]", v.AsString())
+}
+
+func TestSpanEvents_ErrorWithoutErr(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("").Start(context.Background(), "testSpanEvents")
+
+	otelctx.NewSpanEvents(logtest.New(), otelctx.SpanEventsConfig{}).Named("test").WithContext(ctx).Error("no result", nil)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "no result", spans[0].Events()[0].Name)
+}
+
+func TestSpanEvents_NoSpan(t *testing.T) {
+	tl := logtest.New()
+	logger := otelctx.NewSpanEvents(tl, otelctx.SpanEventsConfig{})
+
+	assert.NotPanics(t, func() {
+		logger.Error("failed", errors.New("oops"))
+		logger.WithContext(context.Background()).Warn("warn")
+	})
+	assert.Equal(t, 2, tl.Len())
+}
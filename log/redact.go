@@ -0,0 +1,360 @@
+package log
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskStyle define how Redactor masks sensitive values.
+type MaskStyle uint8
+
+const (
+	// FullMask replaces the value with "[REDACTED]".
+	FullMask MaskStyle = iota
+	// HashMask replaces the value with "sha256:" followed by the first 16 hex digits
+	// of the value's SHA-256 hash, this allows correlating logs without revealing the value.
+	HashMask
+	// Last4Mask replaces all but the last 4 characters with "*", values with 4 or less
+	// characters are fully replaced.
+	Last4Mask
+)
+
+const redactedText = "[REDACTED]"
+
+// Redactable is implemented by types which know how to redact themselves, ie. user object
+// which contains password or email. Redactor replaces the field value with the value
+// returned by Redact before it's encoded.
+type Redactable interface {
+	Redact() any
+}
+
+// RedactionPolicy define which field values are considered sensitive and how they are masked.
+type RedactionPolicy struct {
+	// Keys are case-insensitive patterns of field keys which values are masked entirely,
+	// "*" matches any sequence of characters, ie. "password" or "*token*". Keys are matched
+	// as a whole and by their last dot separated segment, so "http.authorization" matches
+	// "authorization".
+	Keys []string
+	// Values are patterns matched against string, []string, Stringer and error values,
+	// every match is masked and the rest of the value is kept.
+	Values []*regexp.Regexp
+	// Mask define how sensitive values are masked. Default to FullMask.
+	Mask MaskStyle
+}
+
+// Redactor applies RedactionPolicy to log fields, it's used by logger drivers before the
+// fields are encoded. A nil Redactor returns fields unchanged.
+type Redactor struct {
+	keys   []string
+	values []*regexp.Regexp
+	mask   MaskStyle
+}
+
+// NewRedactor create Redactor which applies policy.
+func NewRedactor(policy RedactionPolicy) *Redactor {
+	keys := make([]string, len(policy.Keys))
+	for i, k := range policy.Keys {
+		keys[i] = strings.ToLower(k)
+	}
+	return &Redactor{
+		keys:   keys,
+		values: append([]*regexp.Regexp(nil), policy.Values...),
+		mask:   policy.Mask,
+	}
+}
+
+// Mask return s masked using configured MaskStyle.
+func (r *Redactor) Mask(s string) string {
+	switch r.mask {
+	case HashMask:
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	case Last4Mask:
+		n := utf8.RuneCountInString(s)
+		if n <= 4 {
+			return strings.Repeat("*", n)
+		}
+		i := len(s)
+		for j := 0; j < 4; j++ {
+			_, size := utf8.DecodeLastRuneInString(s[:i])
+			i -= size
+		}
+		return strings.Repeat("*", n-4) + s[i:]
+	default:
+		return redactedText
+	}
+}
+
+// RedactString masks every part of s which matches RedactionPolicy.Values.
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.values {
+		s = re.ReplaceAllStringFunc(s, r.Mask)
+	}
+	return s
+}
+
+// RedactError return error which message is masked using RedactString, err is returned
+// as is when its message doesn't contain sensitive value. The returned error doesn't
+// wrap err to avoid leaking the original message.
+func (r *Redactor) RedactError(err error) error {
+	err, _ = r.redactError(err)
+	return err
+}
+
+// redactError return redacted err and whether it's changed, errors are not compared
+// since they might not be comparable.
+func (r *Redactor) redactError(err error) (error, bool) {
+	if r == nil || err == nil || len(r.values) == 0 {
+		return err, false
+	}
+	msg := err.Error()
+	if s := r.RedactString(msg); s != msg {
+		return errors.New(s), true
+	}
+	return err, false
+}
+
+// RedactField return field with its value redacted, the value is fully masked when the
+// key matches RedactionPolicy.Keys, replaced when it implements Redactable, then masked
+// partially using RedactionPolicy.Values. Maps, slices and structs are normalised to JSON
+// and redacted at every depth, nested keys are matched by their path, ie. "http.header.authorization",
+// the field value is replaced with the normalised value when anything is redacted.
+func (r *Redactor) RedactField(field LogField) LogField {
+	field, _ = r.redactField(field)
+	return field
+}
+
+// RedactFields return fields with their values redacted using RedactField, fields is
+// returned as is when nothing is redacted, otherwise a new slice is returned.
+func (r *Redactor) RedactFields(fields []LogField) []LogField {
+	if r == nil {
+		return fields
+	}
+	var redacted []LogField
+	for i, f := range fields {
+		rf, changed := r.redactField(f)
+		if !changed && redacted == nil {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]LogField, len(fields))
+			copy(redacted, fields[:i])
+		}
+		redacted[i] = rf
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// redactField return redacted field and whether it's changed, values are not compared
+// since they might not be comparable.
+func (r *Redactor) redactField(field LogField) (LogField, bool) {
+	if r == nil {
+		return field, false
+	}
+	if r.matchKey(field.Key) {
+		return String(field.Key, r.Mask(string(appendFieldValue(nil, field)))), true
+	}
+
+	changed := false
+	switch field.Kind {
+	case AnyKind, ObjectKind, StringerKind:
+		if v, ok := field.Value.(Redactable); ok {
+			field, changed = Field(field.Key, v.Redact()), true
+		} else if field.Kind != StringerKind && isComposite(field.Value) {
+			if v, ok := r.redactComposite(field.Key, field.Value); ok {
+				return Field(field.Key, v), true
+			}
+			return field, false
+		}
+	}
+	if len(r.values) == 0 {
+		return field, changed
+	}
+
+	var s string
+	switch field.Kind {
+	case StringKind:
+		s = field.Str
+	case ErrorKind:
+		if err, ok := field.Value.(error); ok {
+			if rerr, ok := r.redactError(err); ok {
+				return Err(field.Key, rerr), true
+			}
+		}
+		return field, changed
+	case StringerKind, AnyKind:
+		switch v := field.Value.(type) {
+		case string:
+			s = v
+		case error:
+			if rerr, ok := r.redactError(v); ok {
+				return Field(field.Key, rerr), true
+			}
+			return field, changed
+		case []string:
+			if rs, ok := r.redactStrings(v); ok {
+				return Field(field.Key, rs), true
+			}
+			return field, changed
+		case fmt.Stringer:
+			s = v.String()
+		default:
+			return field, changed
+		}
+	default:
+		return field, changed
+	}
+	if rs := r.RedactString(s); rs != s {
+		return String(field.Key, rs), true
+	}
+	return field, changed
+}
+
+// redactStrings return copy of s with every element redacted using RedactString and
+// whether any element is changed.
+func (r *Redactor) redactStrings(s []string) ([]string, bool) {
+	var redacted []string
+	for i, v := range s {
+		rv := r.RedactString(v)
+		if rv == v && redacted == nil {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]string, len(s))
+			copy(redacted, s[:i])
+		}
+		redacted[i] = rv
+	}
+	return redacted, redacted != nil
+}
+
+// isComposite report whether v is map, slice, array or struct, or pointer to them, which
+// may carry sensitive values in nested keys. Errors, Stringers and string slices are
+// redacted using their string value instead.
+func isComposite(v any) bool {
+	switch v.(type) {
+	case nil, []string, []byte, error, fmt.Stringer:
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+// redactComposite normalise v to JSON value and redact it, it return the redacted value and
+// true when anything is redacted. Values which can't be encoded to JSON are not redacted.
+func (r *Redactor) redactComposite(key string, v any) (any, bool) {
+	if len(r.keys) == 0 && len(r.values) == 0 {
+		return v, false
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var jv any
+	if err := dec.Decode(&jv); err != nil {
+		return v, false
+	}
+	return r.redactJSON(key, jv)
+}
+
+// redactJSON redact decoded JSON value v at path in place, it return the redacted value
+// and whether anything is redacted.
+func (r *Redactor) redactJSON(path string, v any) (any, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			p := path + "." + k
+			if r.matchKey(p) {
+				v[k], changed = r.Mask(jsonText(e)), true
+				continue
+			}
+			if re, ok := r.redactJSON(p, e); ok {
+				v[k], changed = re, true
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if re, ok := r.redactJSON(path, e); ok {
+				v[i], changed = re, true
+			}
+		}
+	case string:
+		rs := r.RedactString(v)
+		return rs, rs != v
+	}
+	return v, changed
+}
+
+// jsonText return decoded JSON value v as text, strings are returned without quotes.
+func jsonText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+	key = strings.ToLower(key)
+	last := key
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		last = key[i+1:]
+	}
+	for _, pattern := range r.keys {
+		if matchWildcard(pattern, key) || (last != key && matchWildcard(pattern, last)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard report whether s matches pattern where "*" matches any sequence of characters.
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
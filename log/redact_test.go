@@ -0,0 +1,138 @@
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	Name     string
+	Password string
+}
+
+func (u user) Redact() any {
+	return user{Name: u.Name, Password: "***"}
+}
+
+type tags struct {
+	values []string
+}
+
+func (t tags) Error() string {
+	return "tags"
+}
+
+func TestRedactor_Mask(t *testing.T) {
+	tests := []struct {
+		mask     log.MaskStyle
+		value    string
+		expected string
+	}{
+		{log.FullMask, "hunter2", "[REDACTED]"},
+		{log.HashMask, "hunter2", "sha256:f52fbd32b2b3b86f"},
+		{log.Last4Mask, "4111111111111111", "************1111"},
+		{log.Last4Mask, "kéyé1234", "****1234"},
+		{log.Last4Mask, "123", "***"},
+	}
+	for _, tt := range tests {
+		r := log.NewRedactor(log.RedactionPolicy{Mask: tt.mask})
+		assert.Equal(t, tt.expected, r.Mask(tt.value))
+	}
+}
+
+func TestRedactor_RedactField(t *testing.T) {
+	r := log.NewRedactor(log.RedactionPolicy{
+		Keys:   []string{"Password", "*token*"},
+		Values: []*regexp.Regexp{regexp.MustCompile(`Bearer \S+`)},
+	})
+
+	assert.Equal(t, log.String("password", "[REDACTED]"), r.RedactField(log.String("password", "hunter2")))
+	assert.Equal(t, log.String("user.PASSWORD", "[REDACTED]"), r.RedactField(log.String("user.PASSWORD", "hunter2")))
+	assert.Equal(t, log.String("refreshTokenId", "[REDACTED]"), r.RedactField(log.Int64("refreshTokenId", 1)))
+	assert.Equal(t, log.String("username", "john"), r.RedactField(log.String("username", "john")))
+	assert.Equal(t, log.String("header", "auth: [REDACTED]"), r.RedactField(log.String("header", "auth: Bearer abc.def")))
+	assert.Equal(t, log.String("header", "[REDACTED]"), r.RedactField(log.Field("header", "Bearer abc")))
+	assert.Equal(t, log.Field("user", user{Name: "john", Password: "***"}), r.RedactField(log.Object("user", user{Name: "john", Password: "hunter2"})))
+
+	f := r.RedactField(log.Err("error", errors.New("invalid Bearer abc")))
+	assert.Equal(t, "invalid [REDACTED]", f.Any().(error).Error())
+
+	// uncomparable values must not panic
+	assert.NotPanics(t, func() {
+		r.RedactField(log.Err("error", tags{values: []string{"a"}}))
+		r.RedactFields([]log.LogField{log.Object("m", map[string]int{"a": 1})})
+	})
+}
+
+func TestRedactor_RedactNestedField(t *testing.T) {
+	r := log.NewRedactor(log.RedactionPolicy{
+		Keys:   []string{"password", "authorization"},
+		Values: []*regexp.Regexp{regexp.MustCompile(`[\w.]+@[\w.]+`)},
+	})
+	type request struct {
+		Headers map[string]string
+	}
+	type account struct {
+		Password string
+		Email    string
+		Age      int
+	}
+
+	f := r.RedactField(log.Field("http", request{Headers: map[string]string{"Authorization": "Bearer x", "Accept": "*/*"}}))
+	assert.Equal(t, map[string]any{"Headers": map[string]any{"Authorization": "[REDACTED]", "Accept": "*/*"}}, f.Any())
+
+	f = r.RedactField(log.Object("user", &account{Password: "hunter2", Email: "a@b.io", Age: 20}))
+	assert.Equal(t, map[string]any{"Password": "[REDACTED]", "Email": "[REDACTED]", "Age": json.Number("20")}, f.Any())
+
+	f = r.RedactField(log.Field("headers", []map[string]string{{"authorization": "Bearer x"}}))
+	assert.Equal(t, []any{map[string]any{"authorization": "[REDACTED]"}}, f.Any())
+
+	m := map[string]string{"accept": "*/*"}
+	assert.Equal(t, log.Field("headers", m), r.RedactField(log.Field("headers", m)))
+}
+
+func TestSimpleLogger_RedactionNested(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel, log.WithRedaction(log.RedactionPolicy{
+		Keys: []string{"authorization"},
+	}))
+
+	logger.Info("Request", log.Field("http", map[string]any{"header": map[string]string{"Authorization": "Bearer x"}}))
+	require.Equal(t, 1, len(observer.entries))
+	assert.NotContains(t, observer.entries[0], "Bearer x")
+	assert.Contains(t, observer.entries[0], "[REDACTED]")
+}
+
+func TestRedactor_RedactFields(t *testing.T) {
+	r := log.NewRedactor(log.RedactionPolicy{Keys: []string{"password"}})
+	fields := []log.LogField{log.String("username", "john"), log.Int64("age", 20)}
+	assert.Equal(t, fields, r.RedactFields(fields))
+
+	fields = append(fields, log.String("password", "hunter2"))
+	redacted := r.RedactFields(fields)
+	require.Equal(t, 3, len(redacted))
+	assert.Equal(t, log.String("password", "[REDACTED]"), redacted[2])
+	assert.Equal(t, log.String("password", "hunter2"), fields[2])
+
+	var nilRedactor *log.Redactor
+	assert.Equal(t, fields, nilRedactor.RedactFields(fields))
+}
+
+func TestSimpleLogger_Redaction(t *testing.T) {
+	observer := &logObserver{}
+	logger := log.NewSimpleLogger(observer, log.InfoLogLevel, log.WithRedaction(log.RedactionPolicy{
+		Keys:   []string{"password"},
+		Values: []*regexp.Regexp{regexp.MustCompile(`[\w.]+@[\w.]+`)},
+		Mask:   log.Last4Mask,
+	}))
+
+	logger.With(log.String("password", "hunter2")).Error("Login failed", errors.New("unknown user a@b.io"), log.String("email", "a@b.io"))
+	require.Equal(t, 1, len(observer.entries))
+	assert.Equal(t, "level:error\tmessage:Login failed\terror:unknown user **b.io\tpassword:***ter2\temail:**b.io\n", observer.entries[0][39:])
+}
@@ -0,0 +1,215 @@
+package log
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// SamplerConfig define how Sampler samples logs. Logs are sampled per level and message,
+// the first Initial logs in each Tick are written, then every Thereafter-th log is written
+// and the rest are dropped.
+type SamplerConfig struct {
+	// Tick is the sampling interval. Default to 1 second.
+	Tick time.Duration
+	// Initial is the number of logs with the same level and message written in each Tick.
+	Initial int
+	// Thereafter is the sampling rate after Initial logs are written, every Thereafter-th
+	// log is written. If Thereafter is zero, all logs after the first Initial are dropped.
+	Thereafter int
+}
+
+var _ Logger = &Sampler{}
+
+// Sampler wraps Logger to cap the number of logs written with the same level and message,
+// this avoids flapping dependency from drowning the log pipeline. When logs are dropped,
+// Sampler writes a summary log at the end of the tick containing the number of dropped logs.
+// Fatal and Panic logs are never sampled. When the wrapped logger implements LevelEnabler,
+// logs at disabled levels are skipped before they are counted.
+//
+// Similar to zap's sampler, Sampler is optimized for speed over precision, messages are
+// hashed into fixed number of counters thus different messages might share the same counter.
+type Sampler struct {
+	logger Logger
+	state  *samplerState
+}
+
+// NewSampler create Sampler which wraps logger.
+func NewSampler(logger Logger, config SamplerConfig) *Sampler {
+	if config.Tick <= 0 {
+		config.Tick = time.Second
+	}
+	return &Sampler{
+		logger: logger,
+		state: &samplerState{
+			config: config,
+			logger: logger,
+		},
+	}
+}
+
+// Fatal is never sampled.
+func (s *Sampler) Fatal(msg string, err error, optfields ...LogField) {
+	s.logger.Fatal(msg, err, optfields...)
+}
+
+// Panic is never sampled.
+func (s *Sampler) Panic(msg string, err error, optfields ...LogField) {
+	s.logger.Panic(msg, err, optfields...)
+}
+
+func (s *Sampler) Error(msg string, err error, optfields ...LogField) {
+	if s.Enabled(ErrorLogLevel) && s.state.allow(ErrorLogLevel, msg) {
+		s.logger.Error(msg, err, optfields...)
+	}
+}
+
+func (s *Sampler) Warn(msg string, optfields ...LogField) {
+	if s.Enabled(WarnLogLevel) && s.state.allow(WarnLogLevel, msg) {
+		s.logger.Warn(msg, optfields...)
+	}
+}
+
+func (s *Sampler) Info(msg string, optfields ...LogField) {
+	if s.Enabled(InfoLogLevel) && s.state.allow(InfoLogLevel, msg) {
+		s.logger.Info(msg, optfields...)
+	}
+}
+
+func (s *Sampler) Debug(msg string, optfields ...LogField) {
+	if s.Enabled(DebugLogLevel) && s.state.allow(DebugLogLevel, msg) {
+		s.logger.Debug(msg, optfields...)
+	}
+}
+
+func (s *Sampler) Trace(msg string, optfields ...LogField) {
+	if s.Enabled(TraceLogLevel) && s.state.allow(TraceLogLevel, msg) {
+		s.logger.Trace(msg, optfields...)
+	}
+}
+
+// Enabled return true if log at lv is written by the wrapped logger, it return true when
+// the wrapped logger doesn't implement LevelEnabler.
+func (s *Sampler) Enabled(lv LogLevel) bool {
+	if e, ok := s.logger.(LevelEnabler); ok {
+		return e.Enabled(lv)
+	}
+	return true
+}
+
+// WithContext return Sampler which wraps logger returned by underlying WithContext,
+// the returned Sampler share the same counters.
+func (s *Sampler) WithContext(ctx context.Context) Logger {
+	return &Sampler{logger: s.logger.WithContext(ctx), state: s.state}
+}
+
+// With return Sampler which wraps logger returned by underlying With,
+// the returned Sampler share the same counters.
+func (s *Sampler) With(fields ...LogField) Logger {
+	return &Sampler{logger: s.logger.With(fields...), state: s.state}
+}
+
+// Named return Sampler which wraps logger returned by underlying Named,
+// the returned Sampler share the same counters.
+func (s *Sampler) Named(name string) Logger {
+	return &Sampler{logger: s.logger.Named(name), state: s.state}
+}
+
+const (
+	samplerLevels   = int(ErrorLogLevel-TraceLogLevel) + 1
+	samplerCounters = 1024
+)
+
+// samplerCounter counts logs hashed into it in the current tick, it's updated atomically
+// like zap's sampler counter so concurrent logs don't contend on a lock.
+type samplerCounter struct {
+	resetAt atomic.Int64
+	n       atomic.Int64
+	dropped atomic.Int64
+	msg     atomic.Pointer[string]
+}
+
+// incCheckReset increment the counter and return the count in the current tick, the
+// counter is reset when the tick has passed.
+func (c *samplerCounter) incCheckReset(now int64, tick time.Duration) int64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.n.Add(1)
+	}
+	c.n.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+int64(tick)) {
+		// another goroutine has reset the counter.
+		return c.n.Add(1)
+	}
+	return 1
+}
+
+type samplerState struct {
+	config   SamplerConfig
+	logger   Logger
+	counters [samplerLevels][samplerCounters]samplerCounter
+}
+
+func (s *samplerState) allow(lv LogLevel, msg string) bool {
+	i := int(lv - TraceLogLevel)
+	if i < 0 || i >= samplerLevels {
+		return true
+	}
+	c := &s.counters[i][fnv32a(msg)%samplerCounters]
+
+	now := time.Now().UnixNano()
+	n := c.incCheckReset(now, s.config.Tick)
+	initial, thereafter := int64(s.config.Initial), int64(s.config.Thereafter)
+	if n <= initial {
+		return true
+	}
+	if thereafter > 0 && (n-initial)%thereafter == 0 {
+		return true
+	}
+
+	if c.dropped.Add(1) == 1 {
+		// msg is copied here so it's only allocated once per tick.
+		sampled := msg
+		c.msg.Store(&sampled)
+		time.AfterFunc(time.Duration(c.resetAt.Load()-now), func() {
+			s.report(lv, c)
+		})
+	}
+	return false
+}
+
+// report writes summary of dropped logs using the same level as the dropped logs.
+func (s *samplerState) report(lv LogLevel, c *samplerCounter) {
+	dropped := c.dropped.Swap(0)
+	msg := c.msg.Load()
+	if dropped == 0 || msg == nil {
+		return
+	}
+	fields := []LogField{String("sampledMessage", *msg), Int64("dropped", dropped)}
+	switch lv {
+	case ErrorLogLevel:
+		s.logger.Error("Sampler dropped logs", nil, fields...)
+	case WarnLogLevel:
+		s.logger.Warn("Sampler dropped logs", fields...)
+	case InfoLogLevel:
+		s.logger.Info("Sampler dropped logs", fields...)
+	case DebugLogLevel:
+		s.logger.Debug("Sampler dropped logs", fields...)
+	default:
+		s.logger.Trace("Sampler dropped logs", fields...)
+	}
+}
+
+// fnv32a hash s using FNV-1a without allocating.
+func fnv32a(s string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= prime32
+	}
+	return h
+}
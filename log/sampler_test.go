@@ -0,0 +1,64 @@
+package log_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampler(t *testing.T) {
+	observer := &syncLogObserver{}
+	logger := log.NewSampler(log.NewSimpleLogger(observer, log.InfoLogLevel), log.SamplerConfig{
+		Tick:       50 * time.Millisecond,
+		Initial:    2,
+		Thereafter: 3,
+	})
+
+	for i := 0; i < 10; i++ {
+		logger.With(log.Int64("i", int64(i))).Error("Something went wrong", errors.New("oopsie"))
+	}
+	logger.Info("Hello")
+
+	// logs 1st, 2nd, 5th and 8th error, and the info
+	entries := observer.Entries()
+	require.Equal(t, 5, len(entries))
+	assert.Contains(t, entries[0], "\ti:0\n")
+	assert.Contains(t, entries[1], "\ti:1\n")
+	assert.Contains(t, entries[2], "\ti:4\n")
+	assert.Contains(t, entries[3], "\ti:7\n")
+	assert.Contains(t, entries[4], "message:Hello")
+
+	require.Eventually(t, func() bool {
+		return len(observer.Entries()) == 6
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "level:error\tmessage:Sampler dropped logs\tsampledMessage:Something went wrong\tdropped:6\n", observer.Entries()[5][39:])
+
+	// new tick
+	logger.Error("Something went wrong", errors.New("oopsie"))
+	assert.Equal(t, 7, len(observer.Entries()))
+}
+
+func TestSampler_DisabledLevel(t *testing.T) {
+	observer := &syncLogObserver{}
+	simple := log.NewSimpleLogger(observer, log.InfoLogLevel)
+	logger := log.NewSampler(simple, log.SamplerConfig{
+		Tick:    time.Minute,
+		Initial: 1,
+	})
+
+	// disabled logs don't use sampling budget.
+	for i := 0; i < 10; i++ {
+		logger.Debug("Hello")
+	}
+	simple.Level().SetLevel(log.DebugLogLevel)
+	logger.Debug("Hello")
+	logger.Debug("Hello")
+
+	entries := observer.Entries()
+	require.Equal(t, 1, len(entries))
+	assert.Contains(t, entries[0], "level:debug\tmessage:Hello")
+}
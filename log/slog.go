@@ -0,0 +1,365 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// slog.Level used by SlogLogger to write log at levels which slog doesn't define.
+const (
+	// SlogFatalLevel is slog.Level used to write log at FatalLogLevel.
+	SlogFatalLevel = slog.LevelError + 4
+	// SlogPanicLevel is slog.Level used to write log at PanicLogLevel.
+	SlogPanicLevel = slog.LevelError + 2
+	// SlogTraceLevel is slog.Level used to write log at TraceLogLevel.
+	SlogTraceLevel = slog.LevelDebug - 4
+)
+
+var _ slog.Handler = &SlogHandler{}
+
+// RecordLogger is optionally implemented by Logger which can write log on behalf of another
+// logging frontend, ie. SlogHandler. pc is program counter of the call site which is written
+// as log caller, zero means unknown. ctx is used as if it was passed to Logger.WithContext
+// when it's not nil. lv is at most ErrorLogLevel, thus LogRecord never exits nor panics.
+type RecordLogger interface {
+	LogRecord(ctx context.Context, pc uintptr, lv LogLevel, msg string, err error, fields ...LogField)
+}
+
+// SlogHandler implements slog.Handler which forwards records into Logger, this allows
+// libraries using log/slog to write into the same output as Logger. Record attributes
+// are converted into LogField, and attributes inside groups use dotted key, ie. "http.status".
+type SlogHandler struct {
+	logger Logger
+	prefix string
+	fields []LogField
+}
+
+// NewSlogHandler create SlogHandler which forwards records into logger. If logger is nil,
+// records will be forwarded into global logger at the time they are handled, thus SlogHandler
+// follows subsequent SetLogger calls.
+//
+//	slog.SetDefault(slog.New(log.NewSlogHandler(nil)))
+func NewSlogHandler(logger Logger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+func (h *SlogHandler) getLogger() Logger {
+	if h.logger == nil {
+		return GetLogger()
+	}
+	return h.logger
+}
+
+// Enabled return whether underlying Logger writes log at the level mapped from lvl when
+// it implements LevelEnabler, otherwise it return true and the level is filtered by
+// underlying Logger.
+func (h *SlogHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	if e, ok := h.getLogger().(LevelEnabler); ok {
+		return e.Enabled(FromSlogLevel(lvl))
+	}
+	return true
+}
+
+// Handle forwards slog.Record into underlying Logger using LogLevel mapped from record level,
+// see FromSlogLevel. Record at FatalLogLevel or PanicLogLevel is written using Logger.Error to
+// avoid the handler from exiting the program or panicking. When the record is written using Logger.Error, the attribute
+// with "error" or "err" key which value is an error will be passed as Error's err.
+//
+// When underlying Logger implements RecordLogger, the record is written using LogRecord along
+// with its ctx and PC, otherwise ctx is passed to Logger.WithContext unless it's empty
+// context, ie. context.Background used by slog.Info.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	logger := h.getLogger()
+
+	fields := make([]LogField, 0, len(h.fields)+r.NumAttrs())
+	fields = append(fields, h.fields...)
+	var err error
+	r.Attrs(func(a slog.Attr) bool {
+		if r.Level >= slog.LevelError && err == nil && h.prefix == "" && (a.Key == "error" || a.Key == "err") {
+			if e, ok := a.Value.Resolve().Any().(error); ok {
+				err = e
+				return true
+			}
+		}
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	lv := FromSlogLevel(r.Level)
+	if lv > ErrorLogLevel {
+		lv = ErrorLogLevel
+	}
+	if rl, ok := logger.(RecordLogger); ok {
+		rl.LogRecord(ctx, r.PC, lv, r.Message, err, fields...)
+		return nil
+	}
+	if ctx != nil && ctx != context.Background() && ctx != context.TODO() {
+		logger = logger.WithContext(ctx)
+	}
+	switch lv {
+	case ErrorLogLevel:
+		logger.Error(r.Message, err, fields...)
+	case WarnLogLevel:
+		logger.Warn(r.Message, fields...)
+	case InfoLogLevel:
+		logger.Info(r.Message, fields...)
+	case DebugLogLevel:
+		logger.Debug(r.Message, fields...)
+	default:
+		logger.Trace(r.Message, fields...)
+	}
+	return nil
+}
+
+// WithAttrs return new SlogHandler which add attrs to every record it handles.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = make([]LogField, 0, len(h.fields)+len(attrs))
+	h2.fields = append(h2.fields, h.fields...)
+	for _, a := range attrs {
+		h2.fields = appendAttr(h2.fields, h.prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup return new SlogHandler which prefix subsequent attributes key with name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func appendAttr(fields []LogField, prefix string, a slog.Attr) []LogField {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix = prefix + a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			fields = appendAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Key == "" {
+		return fields
+	}
+
+	k := prefix + a.Key
+	switch v.Kind() {
+	case slog.KindString:
+		return append(fields, String(k, v.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(k, v.Int64()))
+	case slog.KindBool:
+		return append(fields, Bool(k, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(k, v.Duration()))
+	case slog.KindTime:
+		return append(fields, Time(k, v.Time()))
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, Err(k, err))
+		}
+		return append(fields, Field(k, v.Any()))
+	default:
+		return append(fields, Field(k, v.Any()))
+	}
+}
+
+var _ Logger = &SlogLogger{}
+
+// SlogLogger implements Logger which writes log using slog.Handler, this allows
+// Logger to be used with any slog.Handler implementation. Logger name is written
+// in "logger" attribute. Global hooks are called after the record is handled, see AddHook.
+type SlogLogger struct {
+	h    slog.Handler
+	ctx  context.Context
+	name string
+	// fields bound using With, only used to build HookEntry.
+	fields []LogField
+}
+
+// NewSlogLogger create SlogLogger which writes log using h.
+func NewSlogLogger(h slog.Handler) *SlogLogger {
+	return &SlogLogger{h: h}
+}
+
+// Fatal writes log at SlogFatalLevel then call Exit(1).
+func (l *SlogLogger) Fatal(msg string, err error, optfields ...LogField) {
+	l.writeLog(SlogFatalLevel, msg, err, optfields)
+	Exit(1)
+}
+
+// Panic writes log at SlogPanicLevel then panics with msg.
+func (l *SlogLogger) Panic(msg string, err error, optfields ...LogField) {
+	l.writeLog(SlogPanicLevel, msg, err, optfields)
+	panic(msg)
+}
+
+func (l *SlogLogger) Error(msg string, err error, optfields ...LogField) {
+	l.writeLog(slog.LevelError, msg, err, optfields)
+}
+
+func (l *SlogLogger) Warn(msg string, optfields ...LogField) {
+	l.writeLog(slog.LevelWarn, msg, nil, optfields)
+}
+
+func (l *SlogLogger) Info(msg string, optfields ...LogField) {
+	l.writeLog(slog.LevelInfo, msg, nil, optfields)
+}
+
+func (l *SlogLogger) Debug(msg string, optfields ...LogField) {
+	l.writeLog(slog.LevelDebug, msg, nil, optfields)
+}
+
+func (l *SlogLogger) Trace(msg string, optfields ...LogField) {
+	l.writeLog(SlogTraceLevel, msg, nil, optfields)
+}
+
+// WithContext return SlogLogger which pass ctx to slog.Handler, and writes fields
+// extracted from ctx using registered ContextExtractor.
+func (l *SlogLogger) WithContext(ctx context.Context) Logger {
+	l2 := *l
+	l2.ctx = ctx
+	return &l2
+}
+
+// With return SlogLogger which handler is created using slog.Handler.WithAttrs.
+func (l *SlogLogger) With(fields ...LogField) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, fieldToAttr(field))
+	}
+	l2 := *l
+	l2.h = l.h.WithAttrs(attrs)
+	l2.fields = make([]LogField, 0, len(l.fields)+len(fields))
+	l2.fields = append(l2.fields, l.fields...)
+	l2.fields = append(l2.fields, fields...)
+	return &l2
+}
+
+func (l *SlogLogger) Named(name string) Logger {
+	l2 := *l
+	l2.name = joinName(l.name, name)
+	return &l2
+}
+
+func (l *SlogLogger) writeLog(lvl slog.Level, msg string, err error, optfields []LogField) {
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.h.Enabled(ctx, lvl) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip [Callers, writeLog, caller of writeLog]
+	r := slog.NewRecord(time.Now(), lvl, msg, pcs[0])
+	if l.name != "" {
+		r.AddAttrs(slog.String("logger", l.name))
+	}
+	if err != nil {
+		r.AddAttrs(slog.Any("error", err))
+	}
+	for _, field := range optfields {
+		r.AddAttrs(fieldToAttr(field))
+	}
+	var ctxfields []LogField
+	if l.ctx != nil {
+		ctxfields = FieldsFromContext(l.ctx)
+		for _, field := range ctxfields {
+			r.AddAttrs(fieldToAttr(field))
+		}
+	}
+	_ = l.h.Handle(ctx, r)
+
+	RunHooks(nil, func() HookEntry {
+		fields := make([]LogField, 0, len(l.fields)+len(optfields)+len(ctxfields))
+		fields = append(fields, l.fields...)
+		fields = append(fields, optfields...)
+		fields = append(fields, ctxfields...)
+		return HookEntry{
+			Time:    r.Time,
+			Level:   FromSlogLevel(lvl),
+			Name:    l.name,
+			Message: msg,
+			Err:     err,
+			Fields:  fields,
+			Context: l.ctx,
+		}
+	})
+}
+
+func fieldToAttr(field LogField) slog.Attr {
+	switch field.Kind {
+	case StringKind:
+		return slog.String(field.Key, field.Str)
+	case Int64Kind:
+		return slog.Int64(field.Key, field.Int)
+	case BoolKind:
+		return slog.Bool(field.Key, field.Int == 1)
+	case DurationKind:
+		return slog.Duration(field.Key, time.Duration(field.Int))
+	case TimeKind:
+		return slog.Time(field.Key, field.time())
+	case StringerKind:
+		// fmt writes "<nil>" for nil and typed nil pointer which String panics.
+		return slog.String(field.Key, fmt.Sprint(field.Value))
+	default:
+		return slog.Any(field.Key, field.Value)
+	}
+}
+
+// ToSlogLevel map LogLevel into slog.Level.
+func ToSlogLevel(lv LogLevel) slog.Level {
+	switch {
+	case lv >= FatalLogLevel:
+		return SlogFatalLevel
+	case lv >= PanicLogLevel:
+		return SlogPanicLevel
+	case lv >= ErrorLogLevel:
+		return slog.LevelError
+	case lv >= WarnLogLevel:
+		return slog.LevelWarn
+	case lv >= InfoLogLevel:
+		return slog.LevelInfo
+	case lv >= DebugLogLevel:
+		return slog.LevelDebug
+	default:
+		return SlogTraceLevel
+	}
+}
+
+// FromSlogLevel map slog.Level into LogLevel, levels between slog levels are
+// mapped into the lower LogLevel, ie. slog.LevelInfo+2 is mapped into InfoLogLevel.
+func FromSlogLevel(lvl slog.Level) LogLevel {
+	switch {
+	case lvl >= SlogFatalLevel:
+		return FatalLogLevel
+	case lvl >= SlogPanicLevel:
+		return PanicLogLevel
+	case lvl >= slog.LevelError:
+		return ErrorLogLevel
+	case lvl >= slog.LevelWarn:
+		return WarnLogLevel
+	case lvl >= slog.LevelInfo:
+		return InfoLogLevel
+	case lvl >= slog.LevelDebug:
+		return DebugLogLevel
+	default:
+		return TraceLogLevel
+	}
+}
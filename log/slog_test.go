@@ -0,0 +1,127 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandler(t *testing.T) {
+	observer := &logObserver{}
+	logger := slog.New(log.NewSlogHandler(log.NewSimpleLogger(observer, log.InfoLogLevel)))
+
+	logger.Debug("Hello")
+	logger.With("requestId", "abc").WithGroup("http").Info("Hello", "status", 200, slog.Group("req", "method", "GET"))
+	logger.Error("Something went wrong", "error", errors.New("oopsie"), "v1", true)
+
+	require.Equal(t, 2, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	assert.Equal(t, "level:info\tmessage:Hello\trequestId:abc\thttp.status:200\thttp.req.method:GET\n", observer.entries[0][39:])
+	assert.Greater(t, len(observer.entries[1]), 40)
+	assert.Equal(t, "level:error\tmessage:Something went wrong\terror:oopsie\tv1:true\n", observer.entries[1][39:])
+}
+
+func TestSlogHandler_GlobalLogger(t *testing.T) {
+	defer log.SetLogger(log.GetLogger())
+
+	logger := slog.New(log.NewSlogHandler(nil))
+	observer := &logObserver{}
+	setGlobalLogger(observer)
+
+	logger.Info("Hello")
+	require.Equal(t, 1, len(observer.entries))
+	assert.Greater(t, len(observer.entries[0]), 40)
+	assert.Equal(t, "level:info\tmessage:Hello\n", observer.entries[0][39:])
+}
+
+// contextCounter counts WithContext calls of the wrapped Logger.
+type contextCounter struct {
+	log.Logger
+	n int
+}
+
+func (c *contextCounter) WithContext(ctx context.Context) log.Logger {
+	c.n++
+	return c.Logger.WithContext(ctx)
+}
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	h := log.NewSlogHandler(log.NewSimpleLogger(&logObserver{}, log.InfoLogLevel))
+	assert.False(t, h.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, h.Enabled(context.Background(), log.SlogFatalLevel))
+
+	// Logger which doesn't implement LevelEnabler filters the level itself.
+	h = log.NewSlogHandler(&log.NoOpLogger{})
+	assert.True(t, h.Enabled(context.Background(), slog.LevelDebug))
+}
+
+func TestSlogHandler_Context(t *testing.T) {
+	observer := &logObserver{}
+	counter := &contextCounter{Logger: log.NewSimpleLogger(observer, log.InfoLogLevel)}
+	logger := slog.New(log.NewSlogHandler(counter))
+
+	logger.Info("Hello")
+	assert.Equal(t, 0, counter.n)
+	logger.InfoContext(log.ContextWithRequestID(context.Background(), "abc"), "Hello")
+	assert.Equal(t, 1, counter.n)
+
+	require.Equal(t, 2, len(observer.entries))
+	assert.Equal(t, "level:info\tmessage:Hello\trequestId:abc\n", observer.entries[1][39:])
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: log.ToSlogLevel(log.InfoLogLevel),
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := log.NewSlogLogger(h).Named("payments").With(log.String("requestId", "abc"))
+
+	writeLog(logger, log.DebugLogLevel)
+	writeLog(logger, log.InfoLogLevel, log.Int64("status", 200))
+	writeErrorLog(logger, errors.New("oopsie"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.Equal(t, "level=INFO msg=Hello requestId=abc logger=payments status=200", lines[0])
+	assert.Equal(t, `level=ERROR msg="Something went wrong" requestId=abc logger=payments error=oopsie`, lines[1])
+}
+
+func TestSlogLogger_NilStringer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	var typedNil *ptrStringer
+	writeLog(logger, log.InfoLogLevel, log.Stringer("typed", typedNil))
+	assert.Equal(t, "level=INFO msg=Hello typed=<nil>\n", buf.String())
+}
+
+func TestFromSlogLevel(t *testing.T) {
+	assert.Equal(t, log.TraceLogLevel, log.FromSlogLevel(slog.LevelDebug-4))
+	assert.Equal(t, log.DebugLogLevel, log.FromSlogLevel(slog.LevelDebug))
+	assert.Equal(t, log.InfoLogLevel, log.FromSlogLevel(slog.LevelInfo+2))
+	assert.Equal(t, log.WarnLogLevel, log.FromSlogLevel(slog.LevelWarn))
+	assert.Equal(t, log.ErrorLogLevel, log.FromSlogLevel(slog.LevelError))
+	assert.Equal(t, log.PanicLogLevel, log.FromSlogLevel(log.SlogPanicLevel))
+	assert.Equal(t, log.FatalLogLevel, log.FromSlogLevel(log.SlogFatalLevel))
+}
@@ -2,7 +2,10 @@ module github.com/hexastack-dev/devkit-go/security
 
 go 1.20
 
-require github.com/stretchr/testify v1.8.1
+require (
+	github.com/hexastack-dev/devkit-go/log v0.0.0-20230222095826-0273cccb4b1e
+	github.com/stretchr/testify v1.8.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
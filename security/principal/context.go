@@ -1,6 +1,10 @@
 package principal
 
-import "context"
+import (
+	"context"
+
+	"github.com/hexastack-dev/devkit-go/log"
+)
 
 type contextKey string
 
@@ -33,3 +37,12 @@ func UserFromContext(ctx context.Context) (*User, bool) {
 	}
 	return nil, false
 }
+
+// UserExtractor is log.ContextExtractor which extract id of user stored in the context as
+// "userId" field, register it using log.AddContextExtractor.
+func UserExtractor(ctx context.Context) []log.LogField {
+	if u, ok := UserFromContext(ctx); ok && u != nil {
+		return []log.LogField{log.String("userId", u.Id)}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hexastack-dev/devkit-go/log"
 	"github.com/hexastack-dev/devkit-go/security/principal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,10 @@ func TestContext(t *testing.T) {
 		}
 	}
 }
+
+func TestUserExtractor(t *testing.T) {
+	assert.Nil(t, principal.UserExtractor(context.TODO()))
+
+	ctx := principal.ContextWithUser(context.TODO(), &principal.User{Id: "123abc"})
+	assert.Equal(t, []log.LogField{log.String("userId", "123abc")}, principal.UserExtractor(ctx))
+}
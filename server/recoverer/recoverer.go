@@ -14,9 +14,9 @@ var recovererContextKey = contextKey("recoverer")
 
 func writeServerError(w http.ResponseWriter, r *http.Request) {
 	if err, ok := GetErrFromContext(r.Context()); ok {
-		log.Error("Panic occured", err)
+		log.WithContext(r.Context()).Error("Panic occured", err)
 	} else {
-		log.Error("Panic occured", errors.New("unknown panic", errors.WithTag(1)))
+		log.WithContext(r.Context()).Error("Panic occured", errors.New("unknown panic", errors.WithTag(1)))
 	}
 	w.WriteHeader(http.StatusInternalServerError)
 }
@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
 	"github.com/hexastack-dev/devkit-go/server/recoverer"
 )
 
@@ -69,3 +71,16 @@ func TestRecoverer_CustomHandler(t *testing.T) {
 	}
 	res.Body.Close()
 }
+
+func TestRecoverer_LogPanic(t *testing.T) {
+	logger := logtest.SetGlobal(t)
+	h := recoverer.New(nil)(http.HandlerFunc(handleHello))
+	req, _ := http.NewRequest("GET", "/hello", nil)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	e := logger.AssertLogged(t, log.ErrorLogLevel, "Panic occured")
+	if e.Err == nil || e.Err.Error() != "Ooopsie" {
+		t.Errorf("logged error should be the recovered panic: %v", e.Err)
+	}
+}
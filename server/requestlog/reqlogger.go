@@ -16,6 +16,8 @@ type ReqLogger struct {
 	filteredHeaders map[string]bool
 }
 
+// NewReqLogger create ReqLogger which writes request log using logger, logger carried by
+// the request context is used when logger is nil, see ContextLogger.
 func NewReqLogger(logger log.Logger, filteredHeaders []string) *ReqLogger {
 	if len(filteredHeaders) == 0 {
 		filteredHeaders = DefaultFilteredHeaders
@@ -61,13 +63,33 @@ func (s *ReqLogger) Log(ent *Entry) {
 	r.Response.StatusCode = ent.Status
 	r.Response.Size = ent.ResponseHeaderSize + ent.ResponseBodySize
 
-	s.log.WithContext(ent.Request.Context()).Info(msg,
+	logger := s.log
+	if logger == nil {
+		logger = log.FromContext(ent.Request.Context())
+	}
+	logger.WithContext(ent.Request.Context()).Info(msg,
 		log.Field("status", ent.Status),
 		log.Field("elapsedTime", ent.Latency),
 		log.Field("http", r),
 	)
 }
 
+// ContextLogger returns a middleware that stores logger, bound with request method and path,
+// in the request context, so handlers can log using log.WithContext or log.FromContext.
+// Logger carried by the request context, or global logger, is used when logger is nil.
+func ContextLogger(logger log.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l := logger
+			if l == nil {
+				l = log.FromContext(r.Context())
+			}
+			l = l.With(log.String("requestMethod", r.Method), log.String("requestPath", r.URL.Path))
+			next.ServeHTTP(w, r.WithContext(log.ContextWithLogger(r.Context(), l)))
+		})
+	}
+}
+
 func getScheme(r *http.Request) string {
 	if r.TLS != nil {
 		return "https"
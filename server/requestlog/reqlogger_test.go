@@ -0,0 +1,30 @@
+package requestlog_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
+	"github.com/hexastack-dev/devkit-go/server/requestlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextLogger(t *testing.T) {
+	global := logtest.SetGlobal(t)
+	logger := logtest.New()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.WithContext(r.Context()).Info("Hello")
+	})
+
+	requestlog.ContextLogger(logger)(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/foo?bar=baz", nil))
+	ent := logger.AssertLogged(t, log.InfoLogLevel, "Hello")
+	assert.Equal(t, http.MethodGet, ent.FieldValue("requestMethod"))
+	assert.Equal(t, "/foo", ent.FieldValue("requestPath"))
+	assert.Equal(t, 0, global.Len())
+
+	requestlog.ContextLogger(nil)(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/foo", nil))
+	ent = global.AssertLogged(t, log.InfoLogLevel, "Hello")
+	assert.Equal(t, http.MethodPost, ent.FieldValue("requestMethod"))
+}
@@ -46,6 +46,8 @@ type Server struct {
 	handler        http.Handler
 	wrappedHandler http.Handler
 	healthHandler  health.Handler
+	levelHandler   http.Handler
+	contextLogger  bool
 	once           sync.Once
 	driver         driver.Server
 
@@ -70,6 +72,18 @@ type Options struct {
 
 	// Logger specifies logger to use by Server when specific events occurs.
 	Logger log.Logger
+
+	// ContextLogger enables storing Logger, or global logger if Logger is nil, bound with
+	// request method and path in request context for handlers, request log and panic log,
+	// see requestlog.ContextLogger.
+	ContextLogger bool
+
+	// LogLevelHandler specifies http.Handler to serve /loglevel endpoint, which
+	// allows log level to be changed at runtime, ie. log.AtomicLevel.
+	// The endpoint is served by AdminHandler, not by ListenAndServe, and must not be
+	// exposed publicly since anyone can flood or silence the logs using it.
+	// If nil, the endpoint will not be mounted.
+	LogLevelHandler http.Handler
 }
 
 // New creates a new server. New(nil, nil) is the same as new(Server).
@@ -84,6 +98,8 @@ func New(h http.Handler, opts *Options) *Server {
 		srv.driver = opts.Driver
 
 		srv.logger = opts.Logger
+		srv.levelHandler = opts.LogLevelHandler
+		srv.contextLogger = opts.ContextLogger
 		if opts.PanicHandler != nil {
 			panicHandler = opts.PanicHandler
 		}
@@ -117,6 +133,10 @@ func (srv *Server) init() {
 		h = rwlog.New(func(err error) {
 			getLogger(srv.logger).Error("Error when writing response", err)
 		})(h)
+		if srv.contextLogger {
+			// ContextLogger wraps request log and recoverer, so their logs carry request fields.
+			h = requestlog.ContextLogger(srv.logger)(h)
+		}
 
 		// h = otelhttp.NewHandler(h, os.Args[0])
 		h = otelhttp.NewHandler(h, "")
@@ -132,6 +152,18 @@ func getLogger(logger log.Logger) log.Logger {
 	return logger
 }
 
+// AdminHandler returns http.Handler serving administrative endpoints, ie. /loglevel when
+// Options.LogLevelHandler is set. It is not served by ListenAndServe, serve it on a separate
+// listener which is only reachable internally, ie. bound to localhost, or protect it with
+// authentication.
+func (srv *Server) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	if srv.levelHandler != nil {
+		mux.Handle("/loglevel", srv.levelHandler)
+	}
+	return mux
+}
+
 // ListenAndServe is a wrapper to use wherever http.ListenAndServe is used.
 // It wraps the http.Handler provided to New with a handler that handles tracing and
 // request logging. If the handler is nil, then http.DefaultServeMux will be used.
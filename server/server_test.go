@@ -19,8 +19,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/hexastack-dev/devkit-go/log"
+	"github.com/hexastack-dev/devkit-go/log/logtest"
 	"github.com/hexastack-dev/devkit-go/server/requestlog"
 )
 
@@ -126,6 +129,79 @@ func TestMiddleware(t *testing.T) {
 	}
 }
 
+func TestContextLogger(t *testing.T) {
+	logger := logtest.New()
+	td := new(testDriver)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("oopsie")
+	})
+	s := New(h, &Options{Driver: td, Logger: logger, ContextLogger: true, RequestLogger: requestlog.NewReqLogger(nil, nil)})
+	if err := s.ListenAndServe(":8080"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Reset()
+
+	rr := httptest.NewRecorder()
+	td.handler.ServeHTTP(rr, httptest.NewRequest("GET", "/panic", nil))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("got status %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+	logger.AssertLogged(t, log.ErrorLogLevel, "Panic occured")
+	logger.AssertLogged(t, log.InfoLogLevel, "500 GET http://example.com/panic HTTP/1.1")
+	for _, ent := range logger.Entries() {
+		if got := ent.FieldValue("requestPath"); got != "/panic" {
+			t.Errorf("%q: got requestPath %v, want %q", ent.Message, got, "/panic")
+		}
+	}
+}
+
+func TestContextLogger_Disabled(t *testing.T) {
+	global := logtest.SetGlobal(t)
+	td := new(testDriver)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.WithContext(r.Context()).Info("Hello")
+	})
+	s := New(h, &Options{Driver: td, Logger: logtest.New()})
+	if err := s.ListenAndServe(":8080"); err != nil {
+		t.Fatal(err)
+	}
+
+	td.handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	ent := global.AssertLogged(t, log.InfoLogLevel, "Hello")
+	if _, ok := ent.Field("requestPath"); ok {
+		t.Error("got requestPath field, want none when ContextLogger is disabled")
+	}
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	level := log.NewAtomicLevel(log.InfoLogLevel)
+	td := new(testDriver)
+	s := New(http.NotFoundHandler(), &Options{Driver: td, LogLevelHandler: level})
+	err := s.ListenAndServe(":8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// not exposed by the application handler.
+	rr := httptest.NewRecorder()
+	td.handler.ServeHTTP(rr, httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("got status %d, want %d", rr.Code, http.StatusNotFound)
+	}
+	if level.Level() != log.InfoLogLevel {
+		t.Errorf("got level %s, want %s", level.Level(), log.InfoLogLevel)
+	}
+
+	rr = httptest.NewRecorder()
+	s.AdminHandler().ServeHTTP(rr, httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+	if level.Level() != log.DebugLogLevel {
+		t.Errorf("got level %s, want %s", level.Level(), log.DebugLogLevel)
+	}
+}
+
 type testDriverNoTLS string
 
 func (td *testDriverNoTLS) ListenAndServe(addr string, h http.Handler) error {
@@ -89,6 +89,17 @@ func (s *Shutdown) Wait() (os.Signal, error) {
 	}
 }
 
+// OnExit run Listener.OnShutdown in parallel under the same timeout as Wait, it has the same
+// signature as log.ExitHandler so listeners also run when the program exits due to Fatal log.
+//
+//	log.AddExitHandler(s.OnExit)
+func (s *Shutdown) OnExit(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.onShutdown(ctx)
+}
+
 func (s *Shutdown) onShutdown(ctx context.Context) error {
 	var (
 		wg sync.WaitGroup
@@ -1,12 +1,15 @@
 package shutdown
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/hexastack-dev/devkit-go/log"
 )
 
 type signalConsumer struct {
@@ -124,3 +127,43 @@ func TestShutdown(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestShutdown_AsyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, log.AsyncWriterConfig{FlushInterval: time.Hour})
+	aw.Write([]byte("Hello\n"))
+
+	sh := New(time.Second, map[string]Listener{"log": aw})
+	if err := sh.onShutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Hello\n" {
+		t.Errorf("pending log should be flushed on shutdown: %q", buf.String())
+	}
+}
+
+func TestShutdown_OnExit(t *testing.T) {
+	var called bool
+	sh := New(time.Second, map[string]Listener{
+		"db": ListenerFunc(func(ctx context.Context) error {
+			called = true
+			return nil
+		}),
+	})
+
+	var code int
+	log.SetExitFunc(func(c int) { code = c })
+	log.SetExitHandlers(sh.OnExit)
+	defer func() {
+		log.SetExitFunc(nil)
+		log.SetExitHandlers()
+	}()
+
+	log.NewSimpleLogger(&bytes.Buffer{}, log.InfoLogLevel).Fatal("Something went wrong", errors.New("oopsie"))
+	if !called {
+		t.Error("shutdown listener should be called on Fatal")
+	}
+	if code != 1 {
+		t.Errorf("exit code should be 1: %d", code)
+	}
+}